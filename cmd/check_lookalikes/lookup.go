@@ -0,0 +1,107 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-whois
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/atc0005/check-whois/internal/checker"
+	"github.com/atc0005/check-whois/internal/domain"
+	"github.com/atc0005/check-whois/internal/lookalike"
+
+	whoisparser "github.com/likexian/whois-parser"
+)
+
+// candidateResult is the outcome of checking the WHOIS records for a
+// lookalike domain candidate.
+type candidateResult struct {
+
+	// Candidate is the lookalike domain candidate that was checked.
+	Candidate lookalike.Candidate
+
+	// Registered indicates whether the candidate domain is registered.
+	Registered bool
+
+	// Domain is the evaluated metadata for a registered candidate domain.
+	// This is nil if the candidate is not registered or if the WHOIS
+	// metadata could not be evaluated.
+	Domain *domain.Metadata
+
+	// Err records any error encountered while checking the candidate.
+	Err error
+}
+
+// isRecentlyCreated indicates whether the candidate domain is registered and
+// was created within the specified thresholds.
+func (cr candidateResult) isRecentlyCreated() bool {
+	return cr.Domain != nil && cr.Domain.IsRecentlyCreated()
+}
+
+// checkCandidates checks the registration data for the given lookalike
+// domain candidates, limiting the number of lookups performed at the same
+// time to the given concurrency value. Results are returned in the same
+// order as the given candidates.
+func checkCandidates(
+	ctx context.Context,
+	c *checker.Checker,
+	candidates []lookalike.Candidate,
+	concurrency int,
+) []candidateResult {
+
+	names := make([]string, len(candidates))
+	for i, candidate := range candidates {
+		names[i] = candidate.Name
+	}
+
+	outcomes := c.CheckAll(ctx, names, concurrency)
+
+	results := make([]candidateResult, len(candidates))
+	for i, candidate := range candidates {
+		results[i] = checkCandidate(candidate, outcomes[i])
+	}
+
+	return results
+}
+
+// checkCandidate classifies the outcome of checking the registration data
+// for the given lookalike domain candidate; the creation date of registered
+// domains is evaluated.
+func checkCandidate(candidate lookalike.Candidate, outcome checker.Outcome) candidateResult {
+
+	result := candidateResult{Candidate: candidate}
+
+	err := outcome.Err
+	switch {
+	// Domains which are available for registration (including premium
+	// priced domains) or which cannot be registered are not a concern.
+	case errors.Is(err, whoisparser.ErrNotFoundDomain),
+		errors.Is(err, whoisparser.ErrPremiumDomain),
+		errors.Is(err, whoisparser.ErrReservedDomain),
+		errors.Is(err, whoisparser.ErrBlockedDomain):
+		return result
+
+	// Registration data was found, but could not be evaluated.
+	case errors.Is(err, checker.ErrEvaluateFailed):
+		result.Registered = true
+		result.Err = fmt.Errorf("%s: %w", candidate.Name, err)
+
+		return result
+
+	case err != nil:
+		result.Err = fmt.Errorf("%s: %w", candidate.Name, err)
+
+		return result
+	}
+
+	result.Registered = true
+	result.Domain = outcome.Domain
+
+	return result
+}
@@ -0,0 +1,204 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-whois
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+//go:generate go-winres make --product-version=git-tag --file-version=git-tag
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	zlog "github.com/rs/zerolog/log"
+
+	"github.com/atc0005/check-whois/internal/checker"
+	"github.com/atc0005/check-whois/internal/config"
+	"github.com/atc0005/check-whois/internal/domain"
+	"github.com/atc0005/check-whois/internal/lookalike"
+
+	"github.com/atc0005/go-nagios"
+)
+
+func main() {
+
+	plugin := nagios.NewPlugin()
+
+	// defer this from the start so it is the last deferred function to run
+	defer plugin.ReturnCheckResults()
+
+	// Setup configuration by parsing user-provided flags.
+	cfg, cfgErr := config.New(config.AppType{LookalikePlugin: true})
+	switch {
+	case errors.Is(cfgErr, config.ErrVersionRequested):
+		fmt.Println(config.Version())
+
+		return
+
+	case cfgErr != nil:
+		// We're using the standalone Err function from rs/zerolog/log as we
+		// do not have a working configuration.
+		zlog.Err(cfgErr).Msg("Error initializing application")
+		plugin.ServiceOutput = fmt.Sprintf(
+			"%s: Error initializing application",
+			nagios.StateUNKNOWNLabel,
+		)
+		plugin.AddError(cfgErr)
+		plugin.ExitStatusCode = nagios.StateUNKNOWNExitCode
+
+		return
+	}
+
+	now := cfg.Clock().Now().UTC()
+	plugin.WarningThreshold = cfg.CreatedWarning.DescribeSince("Lookalike domain created", now, cfg.DisplayDateFormat())
+	plugin.CriticalThreshold = cfg.CreatedCritical.DescribeSince("Lookalike domain created", now, cfg.DisplayDateFormat())
+
+	if cfg.EmitBranding {
+		// If enabled, show application details at end of notification
+		plugin.BrandingCallback = config.Branding("Notification generated by ")
+	}
+
+	log := cfg.Log.With().
+		Str("domain", cfg.Domain).
+		Logger()
+
+	// Permutation kinds are asserted as valid during config validation.
+	kinds := make([]lookalike.Kind, 0, len(cfg.Permutations))
+	for _, permutation := range cfg.Permutations {
+		kind, _ := lookalike.ParseKind(permutation)
+		kinds = append(kinds, kind)
+	}
+
+	candidates, err := lookalike.Generate(cfg.Domain, kinds, cfg.TLDs)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to generate lookalike domains")
+
+		plugin.AddError(err)
+		plugin.ServiceOutput = fmt.Sprintf(
+			"%s: Error generating lookalike domains for %s domain",
+			nagios.StateUNKNOWNLabel,
+			cfg.Domain,
+		)
+		plugin.ExitStatusCode = nagios.StateUNKNOWNExitCode
+
+		return
+	}
+
+	candidates = excludeIgnored(candidates, cfg.IgnoredDomains)
+
+	log.Debug().
+		Int("candidates", len(candidates)).
+		Str("permutations", cfg.Permutations.String()).
+		Msg("generated lookalike domain candidates")
+
+	// Expiration thresholds are not applicable to lookalike domains and are
+	// not set for this application.
+	c := checker.New(cfg.Lookup(), cfg.CheckerConfig())
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Timeout)
+	defer cancel()
+
+	results := checkCandidates(ctx, c, candidates, cfg.Concurrency)
+
+	var completed int
+	for _, result := range results {
+		if !errors.Is(result.Err, context.DeadlineExceeded) {
+			completed++
+		}
+	}
+
+	if completed < len(results) {
+		log.Error().
+			Err(ctx.Err()).
+			Int("completed", completed).
+			Int("candidates", len(results)).
+			Dur("timeout", cfg.Timeout).
+			Msg("timeout reached before lookalike domain lookups completed")
+
+		state := cfg.TimeoutServiceState()
+
+		plugin.AddError(ctx.Err())
+		plugin.ServiceOutput = fmt.Sprintf(
+			"%s: Timeout (%v) reached during lookalike domain lookups (%d of %d completed) for %s domain",
+			state.Label,
+			cfg.Timeout,
+			completed,
+			len(results),
+			cfg.Domain,
+		)
+		plugin.ExitStatusCode = state.ExitCode
+
+		return
+	}
+
+	summary := evaluateResults(cfg.Domain, results)
+
+	for _, result := range summary.LookupErrors {
+		log.Warn().
+			Err(result.Err).
+			Str("candidate", result.Candidate.Name).
+			Msg("failed to check lookalike domain")
+
+		plugin.AddError(result.Err)
+	}
+
+	if len(summary.RecentlyCreated) > 0 {
+		log.Warn().
+			Int("recently_created", len(summary.RecentlyCreated)).
+			Msg("Recently created lookalike domains found")
+
+		plugin.AddError(domain.ErrDomainRecentlyCreated)
+	}
+
+	if err := plugin.AddPerfData(false, getPerfData(summary)...); err != nil {
+		log.Error().
+			Err(err).
+			Msg("failed to add performance data")
+
+		// Surface the error in plugin output.
+		plugin.AddError(err)
+
+		plugin.ExitStatusCode = nagios.StateUNKNOWNExitCode
+		plugin.ServiceOutput = fmt.Sprintf(
+			"%s: Failed to process performance data metrics",
+			nagios.StateUNKNOWNLabel,
+		)
+
+		return
+	}
+
+	plugin.ServiceOutput = summary.OneLineCheckSummary()
+	plugin.LongServiceOutput = summary.Report()
+	plugin.ExitStatusCode = summary.ServiceState().ExitCode
+
+}
+
+// excludeIgnored removes any candidates matching the given list of ignored
+// (known) lookalike domain names.
+func excludeIgnored(candidates []lookalike.Candidate, ignored []string) []lookalike.Candidate {
+	if len(ignored) == 0 {
+		return candidates
+	}
+
+	ignoredNames := make(map[string]struct{}, len(ignored))
+	for _, name := range ignored {
+		if asciiName, err := domain.ASCIIName(name); err == nil {
+			name = asciiName
+		}
+		ignoredNames[strings.ToLower(name)] = struct{}{}
+	}
+
+	filtered := make([]lookalike.Candidate, 0, len(candidates))
+	for _, candidate := range candidates {
+		if _, ok := ignoredNames[candidate.Name]; !ok {
+			filtered = append(filtered, candidate)
+		}
+	}
+
+	return filtered
+}
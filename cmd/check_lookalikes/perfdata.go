@@ -0,0 +1,37 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-whois
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/atc0005/go-nagios"
+)
+
+// getPerfData generates performance data metrics from the given lookalike
+// domain check results.
+func getPerfData(ls lookalikeSummary) []nagios.PerformanceData {
+	return []nagios.PerformanceData{
+		{
+			Label: "candidates",
+			Value: fmt.Sprintf("%d", len(ls.Results)),
+		},
+		{
+			Label: "registered",
+			Value: fmt.Sprintf("%d", len(ls.Registered)),
+		},
+		{
+			Label: "recently_created",
+			Value: fmt.Sprintf("%d", len(ls.RecentlyCreated)),
+		},
+		{
+			Label: "lookup_errors",
+			Value: fmt.Sprintf("%d", len(ls.LookupErrors)),
+		},
+	}
+}
@@ -0,0 +1,209 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-whois
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/atc0005/check-whois/internal/domain"
+	"github.com/atc0005/go-nagios"
+)
+
+// lookalikeSummary is the evaluated collection of lookalike domain
+// candidate results for a domain.
+type lookalikeSummary struct {
+
+	// Name is the domain used to generate lookalike candidates.
+	Name string
+
+	// Results is the full collection of candidate results.
+	Results []candidateResult
+
+	// Registered is the collection of registered candidates.
+	Registered []candidateResult
+
+	// RecentlyCreated is the collection of registered candidates created
+	// within the specified thresholds.
+	RecentlyCreated []candidateResult
+
+	// LookupErrors is the collection of candidates which could not be
+	// checked.
+	LookupErrors []candidateResult
+}
+
+// evaluateResults groups the given candidate results for evaluation.
+func evaluateResults(name string, results []candidateResult) lookalikeSummary {
+	summary := lookalikeSummary{
+		Name:    name,
+		Results: results,
+	}
+
+	for _, result := range results {
+		if result.Registered {
+			summary.Registered = append(summary.Registered, result)
+		}
+
+		if result.isRecentlyCreated() {
+			summary.RecentlyCreated = append(summary.RecentlyCreated, result)
+		}
+
+		if result.Err != nil {
+			summary.LookupErrors = append(summary.LookupErrors, result)
+		}
+	}
+
+	return summary
+}
+
+// ServiceState returns the appropriate Service Check Status label and exit
+// code for the evaluated lookalike candidate results.
+func (ls lookalikeSummary) ServiceState() nagios.ServiceState {
+
+	var isWarning bool
+	for _, result := range ls.RecentlyCreated {
+		d := result.Domain
+		if d.CreatedCriticalThreshold.Crossed(d.CreatedValue()) {
+			return nagios.ServiceState{
+				Label:    nagios.StateCRITICALLabel,
+				ExitCode: nagios.StateCRITICALExitCode,
+			}
+		}
+
+		if d.CreatedWarningThreshold.Crossed(d.CreatedValue()) {
+			isWarning = true
+		}
+	}
+
+	switch {
+	case isWarning:
+		return nagios.ServiceState{
+			Label:    nagios.StateWARNINGLabel,
+			ExitCode: nagios.StateWARNINGExitCode,
+		}
+
+	// We were unable to check any of the candidates.
+	case len(ls.Results) > 0 && len(ls.LookupErrors) == len(ls.Results):
+		return nagios.ServiceState{
+			Label:    nagios.StateUNKNOWNLabel,
+			ExitCode: nagios.StateUNKNOWNExitCode,
+		}
+
+	default:
+		return nagios.ServiceState{
+			Label:    nagios.StateOKLabel,
+			ExitCode: nagios.StateOKExitCode,
+		}
+	}
+}
+
+// OneLineCheckSummary generates a one-line summary of the lookalike domain
+// check results for display and notification purposes.
+func (ls lookalikeSummary) OneLineCheckSummary() string {
+	return fmt.Sprintf(
+		"%s: %d of %d lookalike domains for %q registered (%d recently created, %d lookup errors)%s",
+		ls.ServiceState().Label,
+		len(ls.Registered),
+		len(ls.Results),
+		domain.UnicodeName(ls.Name),
+		len(ls.RecentlyCreated),
+		len(ls.LookupErrors),
+		nagios.CheckOutputEOL,
+	)
+}
+
+// Report provides an overview of the registered lookalike domains
+// appropriate for display as the LongServiceOutput provided via the web UI
+// or as email or Teams notifications.
+func (ls lookalikeSummary) Report() string {
+
+	var report strings.Builder
+
+	_, _ = fmt.Fprintf(
+		&report,
+		"Lookalike domains for %q:%s%s",
+		domain.UnicodeName(ls.Name),
+		nagios.CheckOutputEOL,
+		nagios.CheckOutputEOL,
+	)
+
+	writeSection := func(title string, results []candidateResult, entry func(candidateResult) string) {
+		_, _ = fmt.Fprintf(&report, "%s:%s", title, nagios.CheckOutputEOL)
+
+		if len(results) == 0 {
+			_, _ = fmt.Fprintf(&report, "* None%s", nagios.CheckOutputEOL)
+		}
+
+		for _, result := range results {
+			_, _ = fmt.Fprintf(
+				&report,
+				"* %s (%s): %s%s",
+				candidateDisplayName(result),
+				result.Candidate.Kind,
+				entry(result),
+				nagios.CheckOutputEOL,
+			)
+		}
+
+		_, _ = fmt.Fprint(&report, nagios.CheckOutputEOL)
+	}
+
+	registeredEntry := func(result candidateResult) string {
+		if result.Domain == nil {
+			return "creation date and registrar unavailable"
+		}
+
+		if !result.Domain.HasCreatedDate() {
+			return fmt.Sprintf(
+				"creation date unspecified, registrar %s",
+				result.Domain.RegistrarName(),
+			)
+		}
+
+		return fmt.Sprintf(
+			"created %s (%s), registrar %s",
+			result.Domain.DateFormat.Format(result.Domain.CreatedDate),
+			domain.FormattedExpiration(result.Domain.CreatedDate, result.Domain.Now()),
+			result.Domain.RegistrarName(),
+		)
+	}
+
+	writeSection("Recently created", ls.RecentlyCreated, registeredEntry)
+	// Recently created domains are listed separately.
+	otherRegistered := make([]candidateResult, 0, len(ls.Registered))
+	for _, result := range ls.Registered {
+		if !result.isRecentlyCreated() {
+			otherRegistered = append(otherRegistered, result)
+		}
+	}
+
+	writeSection("Registered", otherRegistered, registeredEntry)
+	writeSection("Lookup errors", ls.LookupErrors, func(result candidateResult) string {
+		return result.Err.Error()
+	})
+
+	_, _ = fmt.Fprintf(
+		&report,
+		"%d lookalike domain candidates checked.%s",
+		len(ls.Results),
+		nagios.CheckOutputEOL,
+	)
+
+	return report.String()
+}
+
+// candidateDisplayName provides the display name for a candidate result,
+// including the Unicode form for internationalized domain names.
+func candidateDisplayName(result candidateResult) string {
+	unicodeName := domain.UnicodeName(result.Candidate.Name)
+	if unicodeName == result.Candidate.Name {
+		return result.Candidate.Name
+	}
+
+	return fmt.Sprintf("%s [%s]", unicodeName, result.Candidate.Name)
+}
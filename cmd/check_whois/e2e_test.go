@@ -0,0 +1,341 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-whois
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/atc0005/check-whois/internal/whoistest"
+	"github.com/atc0005/go-nagios"
+)
+
+// runPluginEnvVar is the environment variable used to run the plugin
+// instead of the tests when the test binary is executed by end-to-end tests.
+const runPluginEnvVar string = "CHECK_WHOIS_E2E_RUN_PLUGIN"
+
+// e2eAsOf is the evaluation date used by end-to-end tests so that the
+// scripted WHOIS responses produce stable results.
+const e2eAsOf string = "2026-01-15"
+
+// TestMain runs the plugin when requested by end-to-end tests, otherwise
+// the tests are run as usual.
+func TestMain(m *testing.M) {
+	if os.Getenv(runPluginEnvVar) != "" {
+		// The plugin exits via the deferred ReturnCheckResults call.
+		main()
+	}
+
+	os.Exit(m.Run())
+}
+
+// runPlugin runs the plugin as a separate process using the given flags,
+// returning the plugin output and exit code.
+func runPlugin(t *testing.T, args ...string) (string, int) {
+	t.Helper()
+
+	exe, err := os.Executable()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := exec.Command(exe, args...) // #nosec G204 -- test binary
+	cmd.Env = append(os.Environ(), runPluginEnvVar+"=1", "TZ=UTC")
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	// Provide the plugin log messages to help troubleshoot failed tests.
+	t.Cleanup(func() {
+		if t.Failed() {
+			t.Logf("plugin log messages:\n%s", stderr.String())
+		}
+	})
+
+	err = cmd.Run()
+
+	var exitErr *exec.ExitError
+	switch {
+	case errors.As(err, &exitErr):
+		return stdout.String(), exitErr.ExitCode()
+	case err != nil:
+		t.Fatalf("failed to run plugin: %v", err)
+	}
+
+	return stdout.String(), nagios.StateOKExitCode
+}
+
+// registrarResponse provides a registrar WHOIS response for the given
+// domain with the given expiration date.
+func registrarResponse(domainName string, expiration string) string {
+	return "Domain Name: " + strings.ToUpper(domainName) + "\r\n" +
+		"Registry Domain ID: 100000001_DOMAIN_COM-VRSN\r\n" +
+		"Updated Date: 2025-07-09T15:04:11Z\r\n" +
+		"Creation Date: 1998-08-13T04:00:00Z\r\n" +
+		"Registry Expiry Date: " + expiration + "\r\n" +
+		"Registrar: Example Registrar, LLC\r\n" +
+		"Domain Status: clientTransferProhibited https://icann.org/epp#clientTransferProhibited\r\n" +
+		"Registrant Name: Domain Administrator\r\n" +
+		"Registrant Email: hostmaster@" + domainName + "\r\n" +
+		"Name Server: NS1.EXAMPLE-DNS.NET\r\n" +
+		"DNSSEC: unsigned\r\n"
+}
+
+// TestEndToEnd asserts that the plugin reports the expected state, exit
+// code and output for scripted WHOIS server responses.
+func TestEndToEnd(t *testing.T) {
+	t.Parallel()
+
+	const domainName string = "example.com"
+
+	tests := map[string]struct {
+		responses    []whoistest.Response
+		args         []string
+		wantExitCode int
+		wantOutput   string
+		wantQueries  int
+	}{
+		"ok": {
+			responses:    []whoistest.Response{{Body: registrarResponse(domainName, "2027-08-12T04:00:00Z")}},
+			wantExitCode: nagios.StateOKExitCode,
+			wantOutput:   `OK: "example.com" domain registration has 574d 4h remaining (as of 2026-01-15`,
+			wantQueries:  1,
+		},
+		"verbose report": {
+			responses:    []whoistest.Response{{Body: registrarResponse(domainName, "2027-08-12T04:00:00Z")}},
+			args:         []string{"--report-level", "verbose"},
+			wantExitCode: nagios.StateOKExitCode,
+			wantOutput:   "  * Email: hostmaster@example.com",
+			wantQueries:  1,
+		},
+		"privacy mismatch": {
+			responses:    []whoistest.Response{{Body: registrarResponse(domainName, "2027-08-12T04:00:00Z")}},
+			args:         []string{"--expected-privacy", "redacted,proxy", "--privacy-mismatch-state", "critical"},
+			wantExitCode: nagios.StateCRITICALExitCode,
+			wantOutput:   "remaining, registrant privacy is public (expected redacted or proxy)",
+			wantQueries:  1,
+		},
+		"privacy match": {
+			responses:    []whoistest.Response{{Body: registrarResponse(domainName, "2027-08-12T04:00:00Z")}},
+			args:         []string{"--expected-privacy", "public"},
+			wantExitCode: nagios.StateOKExitCode,
+			wantOutput:   "* Registrant Privacy: public",
+			wantQueries:  1,
+		},
+		"registry lock missing": {
+			responses:    []whoistest.Response{{Body: registrarResponse(domainName, "2027-08-12T04:00:00Z")}},
+			args:         []string{"--require-registry-lock", "--require-registrar-lock", "--missing-lock-state", "warning"},
+			wantExitCode: nagios.StateWARNINGExitCode,
+			wantOutput:   "registry lock missing serverTransferProhibited, serverUpdateProhibited, serverDeleteProhibited, registrar lock missing clientUpdateProhibited, clientDeleteProhibited",
+			wantQueries:  1,
+		},
+		"warning": {
+			responses:    []whoistest.Response{{Body: registrarResponse(domainName, "2026-02-05T17:30:12Z")}},
+			wantExitCode: nagios.StateWARNINGExitCode,
+			wantOutput:   `WARNING: "example.com" domain registration has 21d 17h remaining`,
+			wantQueries:  1,
+		},
+		"critical": {
+			responses:    []whoistest.Response{{Body: registrarResponse(domainName, "2026-01-22T15:01:44Z")}},
+			wantExitCode: nagios.StateCRITICALExitCode,
+			wantOutput:   `CRITICAL: "example.com" domain registration has 7d 15h remaining`,
+			wantQueries:  1,
+		},
+		"expired": {
+			responses:    []whoistest.Response{{Body: registrarResponse(domainName, "2026-01-10T09:58:47Z")}},
+			wantExitCode: nagios.StateCRITICALExitCode,
+			wantOutput:   `CRITICAL: "example.com" domain registration EXPIRED 4d 14h ago`,
+			wantQueries:  1,
+		},
+		"auto-renewed": {
+			responses: []whoistest.Response{{Body: strings.Replace(
+				registrarResponse(domainName, "2026-01-10T09:58:47Z"),
+				"Domain Status: clientTransferProhibited",
+				"Domain Status: autoRenewPeriod",
+				1,
+			)}},
+			wantExitCode: nagios.StateWARNINGExitCode,
+			wantOutput:   `WARNING: "example.com" domain registration EXPIRED 4d 14h ago (autoRenewPeriod phase, 75d 9h remaining before the domain is lost)`,
+			wantQueries:  1,
+		},
+		"auto-renewed with grace periods": {
+			responses: []whoistest.Response{{Body: strings.Replace(
+				registrarResponse(domainName, "2026-01-10T09:58:47Z"),
+				"Domain Status: clientTransferProhibited",
+				"Domain Status: autoRenewPeriod",
+				1,
+			)}},
+			args:         []string{"--grace-periods", "com=30:0:0"},
+			wantExitCode: nagios.StateWARNINGExitCode,
+			wantOutput:   "(autoRenewPeriod phase, 25d 9h remaining before the domain is lost)",
+			wantQueries:  1,
+		},
+		"not found": {
+			wantExitCode: nagios.StateUNKNOWNExitCode,
+			wantOutput:   "UNKNOWN: Error parsing WHOIS data for example.com domain",
+			wantQueries:  1,
+		},
+		"rate limited": {
+			responses:    []whoistest.Response{whoistest.RateLimited()},
+			wantExitCode: nagios.StateUNKNOWNExitCode,
+			wantOutput:   "query limit exceeded",
+			wantQueries:  1,
+		},
+		"truncated response": {
+			responses: []whoistest.Response{{
+				Body:     registrarResponse(domainName, "2027-08-12T04:00:00Z"),
+				Truncate: 120,
+			}},
+			wantExitCode: nagios.StateUNKNOWNExitCode,
+			wantOutput:   "UNKNOWN: Expiration date not found in registration data for example.com domain",
+			wantQueries:  1,
+		},
+		"missing expiration date with custom state": {
+			responses: []whoistest.Response{{
+				Body:     registrarResponse(domainName, "2027-08-12T04:00:00Z"),
+				Truncate: 120,
+			}},
+			args:         []string{"--missing-expiration-state", "critical"},
+			wantExitCode: nagios.StateCRITICALExitCode,
+			wantOutput:   "CRITICAL: Expiration date not found in registration data for example.com domain",
+			wantQueries:  1,
+		},
+		"timeout": {
+			responses:    []whoistest.Response{{Delay: 5 * time.Second}},
+			args:         []string{"--timeout", "500ms"},
+			wantExitCode: nagios.StateUNKNOWNExitCode,
+			wantOutput:   "UNKNOWN: Timeout (500ms) reached during WHOIS query (127.0.0.1) for example.com domain",
+			wantQueries:  1,
+		},
+		"timeout with custom state": {
+			responses:    []whoistest.Response{{Delay: 5 * time.Second}},
+			args:         []string{"--timeout", "500ms", "--timeout-state", "critical"},
+			wantExitCode: nagios.StateCRITICALExitCode,
+			wantOutput:   "CRITICAL: Timeout (500ms) reached",
+			wantQueries:  1,
+		},
+		"connection reset": {
+			responses:    []whoistest.Response{{Reset: true}},
+			wantExitCode: nagios.StateUNKNOWNExitCode,
+			wantOutput:   "UNKNOWN: Error fetching WHOIS data for example.com domain",
+			wantQueries:  1,
+		},
+		"retry after connection reset": {
+			responses: []whoistest.Response{
+				{Reset: true},
+				{Body: registrarResponse(domainName, "2027-08-12T04:00:00Z")},
+			},
+			args:         []string{"--retries", "2", "--retry-delay", "10ms"},
+			wantExitCode: nagios.StateOKExitCode,
+			wantOutput:   `OK: "example.com" domain registration has 574d 4h remaining`,
+			wantQueries:  2,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			server := whoistest.NewServer(t)
+			if len(tt.responses) > 0 {
+				server.Handle(domainName, tt.responses...)
+			}
+
+			args := append([]string{
+				"--domain", domainName,
+				"--server", server.Addr,
+				"--as-of", e2eAsOf,
+			}, tt.args...)
+
+			output, exitCode := runPlugin(t, args...)
+
+			if exitCode != tt.wantExitCode {
+				t.Errorf("want exit code %d, got %d\noutput:\n%s", tt.wantExitCode, exitCode, output)
+			}
+
+			if !strings.Contains(output, tt.wantOutput) {
+				t.Errorf("\nwant output containing %q\ngot:\n%s", tt.wantOutput, output)
+			}
+
+			if got := len(server.Queries()); got != tt.wantQueries {
+				t.Errorf("want %d queries, got %d (%v)", tt.wantQueries, got, server.Queries())
+			}
+		})
+	}
+}
+
+// TestEndToEndReferral asserts that the plugin follows a registry referral
+// to the registrar WHOIS server unless referral lookups are disabled.
+func TestEndToEndReferral(t *testing.T) {
+	t.Parallel()
+
+	const domainName string = "example.com"
+
+	tests := map[string]struct {
+		args             []string
+		wantExitCode     int
+		wantOutput       string
+		wantRegistrarHit bool
+	}{
+		"referral followed": {
+			wantExitCode:     nagios.StateWARNINGExitCode,
+			wantOutput:       "Registrant Email: hostmaster@example.com",
+			wantRegistrarHit: true,
+		},
+		"referral lookups disabled": {
+			args:         []string{"--disable-ref-lookups"},
+			wantExitCode: nagios.StateUNKNOWNExitCode,
+			wantOutput:   "UNKNOWN: Expiration date not found in registration data for example.com domain",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			registrar := whoistest.NewServer(t)
+			registrar.Handle(domainName, whoistest.Response{
+				Body: registrarResponse(domainName, "2026-02-05T17:30:12Z"),
+			})
+
+			registry := whoistest.NewServer(t)
+			registry.Handle(domainName, whoistest.Response{
+				// The WHOIS client skips referrals to the same host, so the
+				// registrar WHOIS server is referenced by name.
+				Body: "Domain Name: EXAMPLE.COM\r\n" +
+					whoistest.Referral("localhost:"+registrar.Port()),
+			})
+
+			args := append([]string{
+				"--domain", domainName,
+				"--server", registry.Addr,
+				"--as-of", e2eAsOf,
+			}, tt.args...)
+
+			output, exitCode := runPlugin(t, args...)
+
+			if exitCode != tt.wantExitCode {
+				t.Errorf("want exit code %d, got %d\noutput:\n%s", tt.wantExitCode, exitCode, output)
+			}
+
+			if !strings.Contains(output, tt.wantOutput) {
+				t.Errorf("\nwant output containing %q\ngot:\n%s", tt.wantOutput, output)
+			}
+
+			if got := len(registrar.Queries()) > 0; got != tt.wantRegistrarHit {
+				t.Errorf("want registrar queried %t, got %t", tt.wantRegistrarHit, got)
+			}
+		})
+	}
+}
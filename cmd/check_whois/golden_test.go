@@ -0,0 +1,124 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-whois
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package main
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/atc0005/check-whois/internal/domain"
+	whoisparser "github.com/likexian/whois-parser"
+)
+
+// update indicates whether golden files should be refreshed using the
+// current output instead of being compared against it.
+var update = flag.Bool("update", false, "refresh golden files")
+
+// corpusDir is the directory containing the anonymized raw WHOIS responses
+// shared with the domain package tests.
+const corpusDir string = "../../internal/domain/testdata/whois"
+
+// goldenDir is the directory containing the expected performance data for
+// each raw WHOIS response in the corpus.
+const goldenDir string = "testdata/golden"
+
+// goldenNow is the fixed time used when evaluating the corpus.
+var goldenNow = time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC)
+
+// TestGoldenPerfData asserts that the performance data generated for each
+// raw WHOIS response in the corpus matches the recorded golden file.
+func TestGoldenPerfData(t *testing.T) {
+	t.Parallel()
+
+	files, err := filepath.Glob(filepath.Join(corpusDir, "*.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(files) == 0 {
+		t.Fatalf("no WHOIS responses found in %s", corpusDir)
+	}
+
+	warning, err := domain.ParseThreshold("30")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	critical, err := domain.ParseThreshold("15")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, file := range files {
+		t.Run(filepath.Base(file), func(t *testing.T) {
+			t.Parallel()
+
+			raw, err := os.ReadFile(filepath.Clean(file))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var got strings.Builder
+
+			info, parseErr := whoisparser.Parse(string(raw))
+			var d *domain.Metadata
+			if parseErr == nil {
+				d, parseErr = domain.NewDomain(info, warning, critical)
+			}
+
+			switch {
+			case parseErr != nil:
+				got.WriteString("no performance data: evaluation failed\n")
+
+			default:
+				d.Clock = domain.FixedClock(goldenNow)
+
+				pd, err := domain.PerfData(d)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				for _, metric := range pd {
+					if err := metric.Validate(); err != nil {
+						t.Errorf("invalid performance data %q: %v", metric.Label, err)
+					}
+
+					got.WriteString(metric.String() + "\n")
+				}
+			}
+
+			name := strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+			goldenFile := filepath.Join(goldenDir, name+".golden")
+
+			if *update {
+				if err := os.MkdirAll(goldenDir, 0o750); err != nil {
+					t.Fatal(err)
+				}
+
+				if err := os.WriteFile(goldenFile, []byte(got.String()), 0o600); err != nil {
+					t.Fatal(err)
+				}
+
+				return
+			}
+
+			want, err := os.ReadFile(filepath.Clean(goldenFile))
+			if err != nil {
+				t.Fatalf("failed to read golden file (use -update to create): %v", err)
+			}
+
+			if got.String() != string(want) {
+				t.Errorf("performance data does not match %s\nwant:\n%s\ngot:\n%s", goldenFile, want, got.String())
+			}
+		})
+	}
+}
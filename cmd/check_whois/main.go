@@ -10,18 +10,22 @@
 package main
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"io"
+	"strings"
 	"time"
 
 	zlog "github.com/rs/zerolog/log"
 
+	"github.com/atc0005/check-whois/internal/checker"
 	"github.com/atc0005/check-whois/internal/config"
 	"github.com/atc0005/check-whois/internal/domain"
+	"github.com/atc0005/check-whois/internal/lookup"
+	"github.com/atc0005/check-whois/internal/output"
 
 	"github.com/atc0005/go-nagios"
-	"github.com/likexian/whois"
-	whoisparser "github.com/likexian/whois-parser"
 )
 
 func main() {
@@ -32,7 +36,7 @@ func main() {
 	defer plugin.ReturnCheckResults()
 
 	// Setup configuration by parsing user-provided flags.
-	cfg, cfgErr := config.New()
+	cfg, cfgErr := config.New(config.AppType{Plugin: true})
 	switch {
 	case errors.Is(cfgErr, config.ErrVersionRequested):
 		fmt.Println(config.Version())
@@ -53,21 +57,15 @@ func main() {
 		return
 	}
 
-	// Use provided threshold values to calculate the expiration times that
-	// should trigger either a WARNING or CRITICAL state.
-	now := time.Now().UTC()
-	domainExpireAgeWarning := now.AddDate(0, 0, cfg.AgeWarning)
-	domainExpireAgeCritical := now.AddDate(0, 0, cfg.AgeCritical)
-
-	plugin.WarningThreshold = fmt.Sprintf(
-		"Expires before %v (%d days)",
-		domainExpireAgeWarning.Format(domain.DomainDateLayout),
-		cfg.AgeWarning,
+	// Describe the provided threshold values using the expiration times (or
+	// Nagios ranges) that should trigger either a WARNING or CRITICAL state.
+	now := cfg.Clock().Now().UTC()
+	dateFormat := cfg.DisplayDateFormat()
+	plugin.WarningThreshold = describeThresholds(
+		now, dateFormat, cfg.AgeWarning, cfg.UpdatedWarning, cfg.CreatedWarning,
 	)
-	plugin.CriticalThreshold = fmt.Sprintf(
-		"Expires before %v (%d days)",
-		domainExpireAgeCritical.Format(domain.DomainDateLayout),
-		cfg.AgeCritical,
+	plugin.CriticalThreshold = describeThresholds(
+		now, dateFormat, cfg.AgeCritical, cfg.UpdatedCritical, cfg.CreatedCritical,
 	)
 
 	if cfg.EmitBranding {
@@ -75,73 +73,61 @@ func main() {
 		plugin.BrandingCallback = config.Branding("Notification generated by ")
 	}
 
-	log := cfg.Log.With().
-		Str("domain", cfg.Domain).
-		Logger()
-
-	var whoisRaw string
-	var err error
-
-	client := whois.NewClient()
-
-	// Explicitly set referral lookup behavior. Referral lookups are performed
-	// unless requested otherwise by the sysadmin.
-	client.SetDisableReferral(cfg.DisableReferralLookups)
-
-	switch {
-	case cfg.RegistrarServer != "":
-		whoisRaw, err = client.Whois(cfg.Domain, cfg.RegistrarServer)
-	default:
-		whoisRaw, err = client.Whois(cfg.Domain)
-	}
-	if err != nil {
-		log.Error().Err(err).Msg("failed to query WHOIS data")
-
-		plugin.AddError(err)
-		plugin.ServiceOutput = fmt.Sprintf(
-			"%s: Error fetching WHOIS data for %s domain",
-			nagios.StateUNKNOWNLabel,
-			cfg.Domain,
-		)
-		plugin.ExitStatusCode = nagios.StateUNKNOWNExitCode
+	if !strings.EqualFold(cfg.OutputFormat, config.OutputFormatNagios) {
+		// Results for each domain are written in the monitoring system
+		// native format instead of as Nagios plugin output.
+		plugin.SetOutputTarget(io.Discard)
+		plugin.ExitStatusCode = writeNativeOutput(cfg)
 
 		return
-
 	}
 
-	parsedWhois, err := whoisparser.Parse(whoisRaw)
-	if err != nil {
-		log.Error().Err(err).Msg("failed to parse WHOIS data")
-
-		plugin.AddError(err)
-		plugin.ServiceOutput = fmt.Sprintf(
-			"%s: Error parsing WHOIS data for %s domain",
-			nagios.StateUNKNOWNLabel,
-			cfg.Domain,
-		)
-		plugin.ExitStatusCode = nagios.StateUNKNOWNExitCode
+	log := cfg.Log.With().
+		Str("domain", cfg.Domain).
+		Logger()
 
-		return
+	c := newChecker(cfg)
 
-	}
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Timeout)
+	defer cancel()
 
-	d, err := domain.NewDomain(parsedWhois, domainExpireAgeWarning, domainExpireAgeCritical)
+	d, result, err := c.Check(ctx, cfg.Domain)
 	if err != nil {
-		log.Error().Err(err).Msg("failed to parse WhoisInfo data")
+		switch {
+		case errors.Is(err, context.DeadlineExceeded):
+			log.Error().
+				Err(err).
+				Str("step", lookup.Step(err)).
+				Dur("timeout", cfg.Timeout).
+				Msg("timeout reached before lookup completed")
+		case errors.Is(err, domain.ErrMissingExpirationDate):
+			log.Error().Err(err).Msg("domain expiration date not found")
+		case errors.Is(err, checker.ErrFetchFailed):
+			log.Error().Err(err).Msg("failed to query WHOIS data")
+		case errors.Is(err, checker.ErrParseFailed):
+			log.Error().Err(err).Msg("failed to parse WHOIS data")
+		default:
+			log.Error().Err(err).Msg("failed to parse WhoisInfo data")
+		}
+
+		// The plugin and API server describe failures using the same
+		// wording.
+		state, summary := output.Failure(cfg.Domain, err, cfg.OutputOptions())
 
 		plugin.AddError(err)
-		plugin.ServiceOutput = fmt.Sprintf(
-			"%s: Error parsing WhoisInfo data for %s domain",
-			nagios.StateUNKNOWNLabel,
-			cfg.Domain,
-		)
-		plugin.ExitStatusCode = nagios.StateUNKNOWNExitCode
+		plugin.ServiceOutput = summary
+		plugin.ExitStatusCode = state.ExitCode
 
 		return
-
 	}
 
-	pd, perfDataErr := getPerfData(d, cfg.AgeCritical, cfg.AgeWarning)
+	log.Debug().
+		Str("source", result.Raw.Source).
+		Str("format", string(result.Raw.Format)).
+		Dur("duration", result.Duration).
+		Msg("retrieved registration data")
+
+	pd, perfDataErr := domain.PerfData(d)
 	if perfDataErr != nil {
 		log.Error().
 			Err(perfDataErr).
@@ -176,40 +162,95 @@ func main() {
 		return
 	}
 
+	for _, problem := range result.Problems {
+		plugin.AddError(problem)
+	}
+
 	switch {
 
 	case d.IsExpired():
-
 		log.Error().Msg("Domain has expired")
 
-		plugin.AddError(domain.ErrDomainExpired)
-		plugin.ServiceOutput = d.OneLineCheckSummary()
-		plugin.LongServiceOutput = d.Report()
-		plugin.ExitStatusCode = d.ServiceState().ExitCode
+	case d.IsExpiring():
+		log.Warn().Msg("Domain is expiring")
 
-		return
+	default:
+		log.Debug().Msg("No problems with expiration date for domain detected")
 
-	case d.IsExpiring():
+	}
 
-		log.Warn().Msg("Domain is expiring")
+	if d.IsRecentlyUpdated() {
+		log.Warn().Msg("Domain was recently updated")
+	}
 
-		plugin.AddError(domain.ErrDomainExpiring)
-		plugin.ServiceOutput = d.OneLineCheckSummary()
-		plugin.LongServiceOutput = d.Report()
-		plugin.ExitStatusCode = d.ServiceState().ExitCode
+	if d.IsRecentlyCreated() {
+		log.Warn().Msg("Domain was recently created")
+	}
 
-		return
+	if d.IsRegistryLockMissing() {
+		log.Warn().
+			Strs("missing", d.MissingRegistryLockStatuses()).
+			Msg("Domain registry lock statuses missing")
+	}
 
-	default:
+	if d.IsRegistrarLockMissing() {
+		log.Warn().
+			Strs("missing", d.MissingRegistrarLockStatuses()).
+			Msg("Domain registrar lock statuses missing")
+	}
 
-		log.Debug().Msg("No problems with expiration date for domain detected")
+	if d.IsPrivacyMismatch() {
+		log.Warn().
+			Str("registrant_privacy", string(d.RegistrantPrivacy())).
+			Strs("expected_privacy", cfg.ExpectedPrivacy).
+			Msg("Registrant privacy mode does not match expected mode")
+	}
 
-		plugin.ServiceOutput = d.OneLineCheckSummary()
-		plugin.LongServiceOutput = d.Report()
-		plugin.ExitStatusCode = nagios.StateOKExitCode
+	plugin.ServiceOutput = asOfSummary(d.OneLineCheckSummary(), cfg.AsOf, cfg.DisplayDateFormat())
+	plugin.LongServiceOutput = d.Report()
+	if cfg.VerboseReport() {
+		plugin.LongServiceOutput = d.VerboseReport()
+	}
+	plugin.ExitStatusCode = result.State.ExitCode
 
-		return
+}
+
+// newChecker provides the Checker used to evaluate domains as specified by
+// the plugin thresholds and lookup settings.
+func newChecker(cfg *config.Config) *checker.Checker {
+	return checker.New(cfg.Lookup(), cfg.CheckerConfig())
+}
 
+// describeThresholds provides a description of the given expiration, updated
+// date and created date thresholds for display in plugin output. The
+// optional updated and created date thresholds are omitted if not set. Dates
+// are displayed using the given date format.
+func describeThresholds(now time.Time, format domain.DateFormat, expiration domain.Threshold, updated domain.Threshold, created domain.Threshold) string {
+	descriptions := []string{expiration.DescribeUntil("Expires", now, format)}
+
+	if updated.IsSet() {
+		descriptions = append(descriptions, updated.DescribeSince("Updated", now, format))
+	}
+
+	if created.IsSet() {
+		descriptions = append(descriptions, created.DescribeSince("Created", now, format))
+	}
+
+	return strings.Join(descriptions, "; ")
+}
+
+// asOfSummary notes the date used to evaluate domain metadata in the given
+// one-line summary if an as-of date was specified. The summary is returned
+// as-is otherwise. The as-of date is displayed using the given date format.
+func asOfSummary(summary string, asOf time.Time, format domain.DateFormat) string {
+	if asOf.IsZero() {
+		return summary
 	}
 
+	return fmt.Sprintf(
+		"%s (as of %s)%s",
+		strings.TrimSuffix(summary, nagios.CheckOutputEOL),
+		format.Format(asOf),
+		nagios.CheckOutputEOL,
+	)
 }
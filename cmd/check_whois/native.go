@@ -0,0 +1,154 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-whois
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/atc0005/check-whois/internal/checker"
+	"github.com/atc0005/check-whois/internal/config"
+	"github.com/atc0005/check-whois/internal/notify"
+	"github.com/atc0005/check-whois/internal/output"
+
+	"github.com/atc0005/go-nagios"
+	"github.com/rs/zerolog"
+)
+
+// writeNativeOutput checks each specified domain and writes the results in
+// the monitoring system native output format specified by the
+// configuration. Results are written to the command file if specified, or
+// to stdout otherwise. Webhook notifications are sent for domains whose
+// state has changed since the last notification if configured. The state of
+// each domain is provided by the output; the returned exit code only
+// indicates whether the output could be written and notifications sent.
+func writeNativeOutput(cfg *config.Config) int {
+	log := cfg.Log
+
+	// Values are asserted during configuration validation.
+	format, _ := output.ParseFormat(cfg.OutputFormat)
+
+	notifier, err := cfg.Notifier()
+	if err != nil {
+		log.Error().Err(err).Msg("failed to initialize webhook notifications")
+
+		return nagios.StateUNKNOWNExitCode
+	}
+
+	c := newChecker(cfg)
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Timeout)
+	defer cancel()
+
+	names := cfg.DomainList()
+	outcomes := c.CheckAll(ctx, names, cfg.Concurrency)
+	checkedAt := cfg.Clock().Now()
+
+	results := make([]output.Result, len(outcomes))
+	for i, outcome := range outcomes {
+		if outcome.Err != nil {
+			log.Error().
+				Err(outcome.Err).
+				Str("domain", names[i]).
+				Msg("failed to evaluate domain")
+		}
+
+		results[i] = output.New(names[i], outcome, cfg.OutputOptions(), checkedAt)
+	}
+
+	if err := writeResults(cfg.CommandFile, format, results, cfg.OutputNames()); err != nil {
+		log.Error().
+			Err(err).
+			Str("format", string(format)).
+			Msg("failed to write check results")
+
+		return nagios.StateUNKNOWNExitCode
+	}
+
+	log.Debug().
+		Int("domains", len(results)).
+		Str("format", string(format)).
+		Msg("check results written")
+
+	if notifier != nil {
+		notifyCtx, notifyCancel := context.WithTimeout(context.Background(), cfg.Timeout)
+		defer notifyCancel()
+
+		if notifyTransitions(notifyCtx, notifier, outcomes, results, checkedAt, log) > 0 {
+			return nagios.StateUNKNOWNExitCode
+		}
+	}
+
+	return nagios.StateOKExitCode
+}
+
+// notifyTransitions sends webhook notifications for each evaluated domain
+// whose state has changed since the last notification as of the given
+// time, providing the number of notifications which could not be sent.
+func notifyTransitions(ctx context.Context, notifier *notify.Notifier, outcomes []checker.Outcome, results []output.Result, now time.Time, log zerolog.Logger) int {
+	var failed int
+
+	for i, result := range results {
+		if outcomes[i].Err != nil {
+			continue
+		}
+
+		transition, sent, err := notifier.Observe(ctx, result.Domain, result.State, result.Summary, now)
+		if err != nil {
+			log.Error().
+				Err(err).
+				Str("domain", result.Domain).
+				Msg("failed to send state transition notification")
+
+			failed++
+
+			continue
+		}
+
+		if sent {
+			log.Debug().
+				Str("domain", result.Domain).
+				Str("previous_state", transition.Previous).
+				Str("state", transition.Current).
+				Msg("sent state transition notification")
+		}
+	}
+
+	return failed
+}
+
+// writeResults writes the given check results in the given format to the
+// given command file (or other named pipe), or to stdout if a command file
+// is not specified.
+func writeResults(commandFile string, format output.Format, results []output.Result, names output.Names) error {
+	if commandFile == "" {
+		return output.Write(os.Stdout, format, results, names)
+	}
+
+	// The command file is a named pipe created and read by Nagios; it is
+	// opened for writing without creating or truncating it.
+	f, err := os.OpenFile(filepath.Clean(commandFile), os.O_WRONLY|os.O_APPEND, 0)
+	if err != nil {
+		return fmt.Errorf("failed to open command file: %w", err)
+	}
+
+	if err := output.Write(f, format, results, names); err != nil {
+		_ = f.Close()
+
+		return err
+	}
+
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to close command file: %w", err)
+	}
+
+	return nil
+}
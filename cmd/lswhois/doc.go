@@ -0,0 +1,20 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-whois
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+// Tool used to report the registration details of a list of domains as an
+// inventory (registrar, dates, days left, state, nameservers, DNSSEC and
+// locks) in table, CSV, Markdown or HTML format.
+//
+// See our [GitHub repo]:
+//
+//   - to review documentation (including examples)
+//   - for the latest code
+//   - to file an issue or submit improvements for review and potential
+//     inclusion into the project
+//
+// [GitHub repo]: https://github.com/atc0005/check-whois
+package main
@@ -0,0 +1,196 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-whois
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+//go:generate go-winres make --product-version=git-tag --file-version=git-tag
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+	zlog "github.com/rs/zerolog/log"
+
+	"github.com/atc0005/check-whois/internal/checker"
+	"github.com/atc0005/check-whois/internal/config"
+	"github.com/atc0005/check-whois/internal/inventory"
+	"github.com/atc0005/check-whois/internal/notify"
+
+	"github.com/atc0005/go-nagios"
+)
+
+func main() {
+	os.Exit(run())
+}
+
+// run reports the inventory and provides the exit code for the application.
+// A non-zero exit code is returned if the inventory could not be written or
+// if any domain could not be evaluated.
+func run() int {
+
+	// Setup configuration by parsing user-provided flags.
+	cfg, cfgErr := config.New(config.AppType{Inventory: true})
+	switch {
+	case errors.Is(cfgErr, config.ErrVersionRequested):
+		fmt.Println(config.Version())
+
+		return 0
+
+	case cfgErr != nil:
+		// We're using the standalone Err function from rs/zerolog/log as we
+		// do not have a working configuration.
+		zlog.Err(cfgErr).Msg("Error initializing application")
+
+		return 1
+	}
+
+	log := cfg.Log
+
+	// Values are asserted during configuration validation.
+	format, _ := inventory.ParseFormat(cfg.InventoryFormat)
+	sortKey, _ := inventory.ParseSortKey(cfg.InventorySort)
+
+	notifier, err := cfg.Notifier()
+	if err != nil {
+		log.Error().Err(err).Msg("failed to initialize webhook notifications")
+
+		return 1
+	}
+
+	c := checker.New(cfg.Lookup(), cfg.CheckerConfig())
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Timeout)
+	defer cancel()
+
+	names := cfg.DomainList()
+	outcomes := c.CheckAll(ctx, names, cfg.Concurrency)
+
+	inv := inventory.New(names, outcomes, cfg.Clock().Now(), cfg.DisplayDateFormat())
+	inv.Sort(sortKey)
+
+	for _, row := range inv.Rows {
+		if row.Err != nil {
+			log.Error().
+				Err(row.Err).
+				Str("domain", row.Name).
+				Msg("failed to evaluate domain")
+
+			continue
+		}
+
+		log.Debug().
+			Str("domain", row.Name).
+			Str("state", row.State.Label).
+			Msg("evaluated domain")
+	}
+
+	if err := writeInventory(inv, format, cfg.Output); err != nil {
+		log.Error().Err(err).Msg("failed to write inventory")
+
+		return 1
+	}
+
+	log.Debug().
+		Int("domains", len(inv.Rows)).
+		Int("failed", inv.Failed()).
+		Msg("inventory reported")
+
+	var notifyFailed int
+	if notifier != nil {
+		notifyCtx, notifyCancel := context.WithTimeout(context.Background(), cfg.Timeout)
+		defer notifyCancel()
+
+		notifyFailed = notifyTransitions(notifyCtx, notifier, inv, cfg.Clock().Now(), log)
+	}
+
+	if inv.Failed() > 0 || notifyFailed > 0 {
+		return 1
+	}
+
+	return 0
+}
+
+// notifyTransitions sends webhook notifications for each evaluated domain
+// whose state has changed since the last notification as of the given
+// time, providing the number of notifications which could not be sent.
+func notifyTransitions(ctx context.Context, notifier *notify.Notifier, inv *inventory.Inventory, now time.Time, log zerolog.Logger) int {
+	var failed int
+
+	for _, row := range inv.Rows {
+		if row.Err != nil {
+			continue
+		}
+
+		summary := strings.TrimSuffix(row.Domain.OneLineCheckSummary(), nagios.CheckOutputEOL)
+
+		transition, sent, err := notifier.Observe(ctx, row.Name, row.State.Label, summary, now)
+		if err != nil {
+			log.Error().
+				Err(err).
+				Str("domain", row.Name).
+				Msg("failed to send state transition notification")
+
+			failed++
+
+			continue
+		}
+
+		if sent {
+			log.Debug().
+				Str("domain", row.Name).
+				Str("previous_state", transition.Previous).
+				Str("state", transition.Current).
+				Msg("sent state transition notification")
+		}
+	}
+
+	return failed
+}
+
+// writeInventory writes the given inventory in the given format to the
+// given file, or to stdout if a file is not specified. The file is replaced
+// atomically so that readers never retrieve a partially written inventory.
+func writeInventory(inv *inventory.Inventory, format inventory.Format, path string) error {
+	if path == "" {
+		return inv.Write(os.Stdout, format)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary inventory file: %w", err)
+	}
+
+	// Remove the temporary file if it is not renamed.
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	if err := inv.Write(tmp, format); err != nil {
+		_ = tmp.Close()
+
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to close temporary inventory file: %w", err)
+	}
+
+	// Inventory files are intended to be shared (e.g., as a spreadsheet).
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil { //nolint:gosec
+		return fmt.Errorf("failed to set inventory file permissions: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to replace inventory file: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,90 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-whois
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+//go:generate go-winres make --product-version=git-tag --file-version=git-tag
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	zlog "github.com/rs/zerolog/log"
+
+	"github.com/atc0005/check-whois/internal/checker"
+	"github.com/atc0005/check-whois/internal/config"
+	"github.com/atc0005/check-whois/internal/server"
+)
+
+func main() {
+	os.Exit(run())
+}
+
+// run serves API requests until interrupted and provides the exit code for
+// the application.
+func run() int {
+
+	// Setup configuration by parsing user-provided flags.
+	cfg, cfgErr := config.New(config.AppType{Server: true})
+	switch {
+	case errors.Is(cfgErr, config.ErrVersionRequested):
+		fmt.Println(config.Version())
+
+		return 0
+
+	case cfgErr != nil:
+		// We're using the standalone Err function from rs/zerolog/log as we
+		// do not have a working configuration.
+		zlog.Err(cfgErr).Msg("Error initializing application")
+
+		return 1
+	}
+
+	log := cfg.Log
+
+	c := checker.New(cfg.Lookup(), cfg.CheckerConfig())
+
+	notifier, err := cfg.Notifier()
+	if err != nil {
+		log.Error().Err(err).Msg("failed to initialize webhook notifications")
+
+		return 1
+	}
+
+	srv := server.New(c, server.Config{
+		Concurrency: cfg.Concurrency,
+		Timeout:     cfg.Timeout,
+		CacheTTL:    cfg.ResultCacheTTL,
+		Normalize:   cfg.NormalizeDomainName,
+		Output:      cfg.OutputOptions(),
+		Notifier:    notifier,
+		Log:         log,
+	})
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	log.Info().
+		Str("listen", cfg.ListenAddress).
+		Int("concurrency", cfg.Concurrency).
+		Dur("result_cache_ttl", cfg.ResultCacheTTL).
+		Msg("serving API requests")
+
+	if err := srv.ListenAndServe(ctx, cfg.ListenAddress); err != nil {
+		log.Error().Err(err).Msg("API server failed")
+
+		return 1
+	}
+
+	log.Info().Msg("API server stopped")
+
+	return 0
+}
@@ -0,0 +1,20 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-whois
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+// Tool used to export the expiration dates of a list of domains as an
+// iCalendar (.ics) calendar with one event per domain expiration date and
+// reminders at the WARNING and CRITICAL thresholds.
+//
+// See our [GitHub repo]:
+//
+//   - to review documentation (including examples)
+//   - for the latest code
+//   - to file an issue or submit improvements for review and potential
+//     inclusion into the project
+//
+// [GitHub repo]: https://github.com/atc0005/check-whois
+package main
@@ -0,0 +1,150 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-whois
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+//go:generate go-winres make --product-version=git-tag --file-version=git-tag
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	zlog "github.com/rs/zerolog/log"
+
+	"github.com/atc0005/check-whois/internal/calendar"
+	"github.com/atc0005/check-whois/internal/checker"
+	"github.com/atc0005/check-whois/internal/config"
+	"github.com/atc0005/go-nagios"
+)
+
+func main() {
+	os.Exit(run())
+}
+
+// run exports the calendar and provides the exit code for the application.
+// A non-zero exit code is returned if the calendar could not be written or
+// if any domain could not be evaluated.
+func run() int {
+
+	// Setup configuration by parsing user-provided flags.
+	cfg, cfgErr := config.New(config.AppType{Calendar: true})
+	switch {
+	case errors.Is(cfgErr, config.ErrVersionRequested):
+		fmt.Println(config.Version())
+
+		return 0
+
+	case cfgErr != nil:
+		// We're using the standalone Err function from rs/zerolog/log as we
+		// do not have a working configuration.
+		zlog.Err(cfgErr).Msg("Error initializing application")
+
+		return 1
+	}
+
+	log := cfg.Log
+
+	c := checker.New(cfg.Lookup(), cfg.CheckerConfig())
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Timeout)
+	defer cancel()
+
+	names := cfg.DomainList()
+	outcomes := c.CheckAll(ctx, names, cfg.Concurrency)
+
+	cal := calendar.New(
+		cfg.CalendarName,
+		[]calendar.Reminder{
+			{Label: nagios.StateWARNINGLabel, Before: cfg.AgeWarning.Duration()},
+			{Label: nagios.StateCRITICALLabel, Before: cfg.AgeCritical.Duration()},
+		},
+		cfg.Clock().Now(),
+	)
+
+	var failed int
+	for i, outcome := range outcomes {
+		if outcome.Err != nil {
+			log.Error().
+				Err(outcome.Err).
+				Str("domain", names[i]).
+				Msg("failed to evaluate domain; omitting from calendar")
+
+			failed++
+
+			continue
+		}
+
+		log.Debug().
+			Str("domain", names[i]).
+			Str("state", outcome.Result.State.Label).
+			Dur("duration", outcome.Result.Duration).
+			Msg("evaluated domain")
+
+		cal.Add(outcome.Domain)
+	}
+
+	if err := writeCalendar(cal, cfg.Output); err != nil {
+		log.Error().Err(err).Msg("failed to write calendar")
+
+		return 1
+	}
+
+	log.Info().
+		Int("events", cal.Len()).
+		Int("failed", failed).
+		Msg("calendar exported")
+
+	if failed > 0 {
+		return 1
+	}
+
+	return 0
+}
+
+// writeCalendar writes the given calendar to the given file, or to stdout if
+// a file is not specified. The file is replaced atomically so that calendar
+// subscribers never retrieve a partially written calendar.
+func writeCalendar(cal *calendar.Calendar, path string) error {
+	if path == "" {
+		_, err := cal.WriteTo(os.Stdout)
+
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary calendar file: %w", err)
+	}
+
+	// Remove the temporary file if it is not renamed.
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	if _, err := cal.WriteTo(tmp); err != nil {
+		_ = tmp.Close()
+
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to close temporary calendar file: %w", err)
+	}
+
+	// Calendar files are intended to be shared (e.g., served by a web
+	// server).
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil { //nolint:gosec
+		return fmt.Errorf("failed to set calendar file permissions: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to replace calendar file: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,257 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-whois
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package calendar
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/atc0005/check-whois/internal/domain"
+)
+
+// DefaultName is the calendar name used if one is not specified.
+const DefaultName string = "Domain Expiration"
+
+// productID identifies the application which generated the calendar.
+const productID string = "-//atc0005//check-whois//EN"
+
+// uidDomain is used to qualify the unique identifier for each event. The
+// identifier is stable across exports so that calendar applications update
+// existing events instead of adding duplicates.
+const uidDomain string = "check-whois.atc0005.github.com"
+
+// lineBreak is the line delimiter required by RFC 5545.
+const lineBreak string = "\r\n"
+
+// maxLineOctets is the maximum length of a content line (excluding the line
+// break) before it is folded.
+const maxLineOctets int = 75
+
+// dateLayout is the layout for DATE values.
+const dateLayout string = "20060102"
+
+// dateTimeLayout is the layout for (UTC) DATE-TIME values.
+const dateTimeLayout string = "20060102T150405Z"
+
+// Reminder is an alarm triggered before the expiration of a domain.
+type Reminder struct {
+
+	// Label describes the reminder (e.g., WARNING).
+	Label string
+
+	// Before is how long before the expiration date the reminder is
+	// triggered.
+	Before time.Duration
+}
+
+// Calendar is a collection of domain expiration events.
+type Calendar struct {
+
+	// Name is the display name of the calendar.
+	Name string
+
+	// Reminders is the collection of alarms added to each event.
+	Reminders []Reminder
+
+	// Stamp is the time the calendar was generated.
+	Stamp time.Time
+
+	// domains is the collection of evaluated domains with one event per
+	// domain expiration date.
+	domains []*domain.Metadata
+}
+
+// New creates a new Calendar with the given name and reminders. The given
+// time is recorded as the time each event was generated.
+func New(name string, reminders []Reminder, stamp time.Time) *Calendar {
+	if name == "" {
+		name = DefaultName
+	}
+
+	return &Calendar{
+		Name:      name,
+		Reminders: reminders,
+		Stamp:     stamp,
+	}
+}
+
+// Add adds an expiration event for each of the given evaluated domains.
+// Nil values are ignored.
+func (c *Calendar) Add(domains ...*domain.Metadata) {
+	for _, d := range domains {
+		if d != nil {
+			c.domains = append(c.domains, d)
+		}
+	}
+}
+
+// Len provides the number of events in the calendar.
+func (c *Calendar) Len() int {
+	return len(c.domains)
+}
+
+// WriteTo writes the calendar in iCalendar format to the given writer.
+func (c *Calendar) WriteTo(w io.Writer) (int64, error) {
+	var ics strings.Builder
+
+	writeLine(&ics, "BEGIN:VCALENDAR")
+	writeLine(&ics, "VERSION:2.0")
+	writeLine(&ics, "PRODID:"+productID)
+	writeLine(&ics, "CALSCALE:GREGORIAN")
+	writeLine(&ics, "METHOD:PUBLISH")
+	writeLine(&ics, "X-WR-CALNAME:"+escapeText(c.Name))
+
+	for _, d := range c.domains {
+		c.writeEvent(&ics, d)
+	}
+
+	writeLine(&ics, "END:VCALENDAR")
+
+	n, err := io.WriteString(w, ics.String())
+	if err != nil {
+		return int64(n), fmt.Errorf("failed to write calendar: %w", err)
+	}
+
+	return int64(n), nil
+}
+
+// writeEvent writes an all-day event on the expiration date of the given
+// domain, along with an alarm for each reminder.
+func (c *Calendar) writeEvent(ics *strings.Builder, d *domain.Metadata) {
+	expires := d.ExpirationDate.UTC()
+	start := time.Date(expires.Year(), expires.Month(), expires.Day(), 0, 0, 0, 0, time.UTC)
+
+	summary := fmt.Sprintf("%s domain registration expires", d.Name)
+
+	writeLine(ics, "BEGIN:VEVENT")
+	writeLine(ics, "UID:"+d.Name+"-expiration@"+uidDomain)
+	writeLine(ics, "DTSTAMP:"+c.Stamp.UTC().Format(dateTimeLayout))
+	writeLine(ics, "DTSTART;VALUE=DATE:"+start.Format(dateLayout))
+	writeLine(ics, "DTEND;VALUE=DATE:"+start.AddDate(0, 0, 1).Format(dateLayout))
+	writeLine(ics, "SUMMARY:"+escapeText(summary))
+	writeLine(ics, "DESCRIPTION:"+escapeText(description(d)))
+	writeLine(ics, "TRANSP:TRANSPARENT")
+
+	for _, reminder := range c.Reminders {
+		if reminder.Before <= 0 {
+			continue
+		}
+
+		writeLine(ics, "BEGIN:VALARM")
+		writeLine(ics, "ACTION:DISPLAY")
+		writeLine(ics, "TRIGGER;RELATED=START:-"+duration(reminder.Before))
+		writeLine(ics, "DESCRIPTION:"+escapeText(fmt.Sprintf(
+			"%s: %s (%s before expiration)",
+			reminder.Label,
+			summary,
+			durationText(reminder.Before),
+		)))
+		writeLine(ics, "END:VALARM")
+	}
+
+	writeLine(ics, "END:VEVENT")
+}
+
+// description provides the event description for the given domain.
+func description(d *domain.Metadata) string {
+	return strings.Join([]string{
+		"Domain: " + d.Name,
+		"Registrar: " + d.RegistrarName(),
+		"Status: " + d.DomainStatus(),
+		"Expiration Date: " + d.DateFormat.Format(d.ExpirationDate),
+		"State: " + d.ServiceState().Label,
+	}, "\n")
+}
+
+// duration formats the given duration as an RFC 5545 DURATION value (e.g.,
+// P30D or PT12H).
+func duration(d time.Duration) string {
+	days := int64(d / (24 * time.Hour))
+	remainder := d % (24 * time.Hour)
+
+	value := "P"
+	if days > 0 {
+		value += fmt.Sprintf("%dD", days)
+	}
+
+	if remainder > 0 || days == 0 {
+		value += "T"
+
+		hours := int64(remainder / time.Hour)
+		minutes := int64(remainder % time.Hour / time.Minute)
+		seconds := int64(remainder % time.Minute / time.Second)
+
+		if hours > 0 {
+			value += fmt.Sprintf("%dH", hours)
+		}
+
+		if minutes > 0 {
+			value += fmt.Sprintf("%dM", minutes)
+		}
+
+		if seconds > 0 || (hours == 0 && minutes == 0) {
+			value += fmt.Sprintf("%dS", seconds)
+		}
+	}
+
+	return value
+}
+
+// durationText provides a human readable description of the given duration
+// (e.g., "30 days" or "36h0m0s").
+func durationText(d time.Duration) string {
+	if d%(24*time.Hour) == 0 {
+		return fmt.Sprintf("%d days", int64(d/(24*time.Hour)))
+	}
+
+	return d.String()
+}
+
+// escapeText escapes the given value for use as an RFC 5545 TEXT value.
+func escapeText(value string) string {
+	replacer := strings.NewReplacer(
+		`\`, `\\`,
+		`;`, `\;`,
+		`,`, `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	)
+
+	return replacer.Replace(value)
+}
+
+// writeLine writes the given content line, folding it into multiple lines
+// of no more than 75 octets as required by RFC 5545. Continuation lines
+// begin with a space which counts towards the limit. Lines are only folded
+// between UTF-8 encoded characters.
+func writeLine(ics *strings.Builder, line string) {
+	limit := maxLineOctets
+
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !isCharBoundary(line, cut) {
+			cut--
+		}
+
+		ics.WriteString(line[:cut])
+		ics.WriteString(lineBreak + " ")
+		line = line[cut:]
+		limit = maxLineOctets - 1
+	}
+
+	ics.WriteString(line)
+	ics.WriteString(lineBreak)
+}
+
+// isCharBoundary indicates whether the given index is the start of a UTF-8
+// encoded character (or the end of the string).
+func isCharBoundary(s string, index int) bool {
+	return index >= len(s) || s[index]&0xC0 != 0x80
+}
@@ -0,0 +1,199 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-whois
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package calendar
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/atc0005/check-whois/internal/domain"
+
+	whoisparser "github.com/likexian/whois-parser"
+)
+
+// TestWriteTo asserts that one all-day event is written per domain with an
+// alarm per reminder and the registrar, status and state in the
+// description.
+func TestWriteTo(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, time.January, 15, 0, 0, 0, 0, time.UTC)
+
+	cal := New("", []Reminder{
+		{Label: "WARNING", Before: 30 * 24 * time.Hour},
+		{Label: "CRITICAL", Before: 36 * time.Hour},
+		{Label: "ignored", Before: 0},
+	}, now)
+
+	registrations := []whoisparser.WhoisInfo{
+		{
+			Domain: &whoisparser.Domain{
+				Domain:         "example.com",
+				ExpirationDate: "2026-02-01",
+				Status:         []string{"clientTransferProhibited", "clientUpdateProhibited"},
+			},
+			Registrar: &whoisparser.Contact{Name: "Example Registrar, Inc."},
+		},
+		{
+			Domain: &whoisparser.Domain{
+				Domain:         "example.net",
+				ExpirationDate: "2027-08-12",
+				Status:         []string{"clientTransferProhibited", "clientUpdateProhibited"},
+			},
+			Registrar: &whoisparser.Contact{Name: "Example Registrar; Europe"},
+		},
+	}
+
+	warning, err := domain.ParseThreshold("30")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	critical, err := domain.ParseThreshold("15")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, info := range registrations {
+		m, err := domain.NewDomain(info, warning, critical)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		m.Clock = domain.FixedClock(now)
+		m.DateFormat = domain.DateFormat{Layout: "02 Jan 2006"}
+		cal.Add(m)
+	}
+
+	// Domains which could not be evaluated are skipped.
+	cal.Add(nil)
+
+	if cal.Len() != 2 {
+		t.Fatalf("want 2 events, got %d", cal.Len())
+	}
+
+	var ics strings.Builder
+	n, err := cal.WriteTo(&ics)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := ics.String()
+	if int(n) != len(got) {
+		t.Errorf("want %d bytes written, got %d", len(got), n)
+	}
+
+	unfolded := strings.ReplaceAll(got, "\r\n ", "")
+
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\n",
+		"X-WR-CALNAME:" + DefaultName + "\r\n",
+		"UID:example.com-expiration@" + uidDomain + "\r\n",
+		"DTSTAMP:20260115T000000Z\r\n",
+		"DTSTART;VALUE=DATE:20260201\r\n",
+		"DTEND;VALUE=DATE:20260202\r\n",
+		"SUMMARY:example.com domain registration expires\r\n",
+		`Registrar: Example Registrar\, Inc.\n`,
+		`Status: clientTransferProhibited\, clientUpdateProhibited\n`,
+		`Expiration Date: 01 Feb 2026\n`,
+		`State: WARNING`,
+		`Registrar: Example Registrar\; Europe\n`,
+		"DTSTART;VALUE=DATE:20270812\r\n",
+		"TRIGGER;RELATED=START:-P30D\r\n",
+		"TRIGGER;RELATED=START:-P1DT12H\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(unfolded, want) {
+			t.Errorf("want calendar to contain %q, got:\n%s", want, got)
+		}
+	}
+
+	if count := strings.Count(got, "BEGIN:VEVENT"); count != 2 {
+		t.Errorf("want 2 events, got %d", count)
+	}
+
+	if count := strings.Count(got, "BEGIN:VALARM"); count != 4 {
+		t.Errorf("want 4 alarms, got %d", count)
+	}
+}
+
+// TestWriteLineFolding asserts that long content lines are folded into
+// lines of no more than 75 octets without splitting UTF-8 characters.
+func TestWriteLineFolding(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		line string
+	}{
+		"short":     {line: "SUMMARY:example.com"},
+		"exact":     {line: "DESCRIPTION:" + strings.Repeat("a", maxLineOctets-len("DESCRIPTION:"))},
+		"long":      {line: "DESCRIPTION:" + strings.Repeat("a", 200)},
+		"multibyte": {line: "DESCRIPTION:" + strings.Repeat("ü", 100)},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var ics strings.Builder
+			writeLine(&ics, tt.line)
+
+			got := ics.String()
+			if !strings.HasSuffix(got, lineBreak) {
+				t.Fatalf("want line break suffix, got %q", got)
+			}
+
+			lines := strings.Split(strings.TrimSuffix(got, lineBreak), lineBreak)
+			for i, line := range lines {
+				if len(line) > maxLineOctets {
+					t.Errorf("line %d exceeds %d octets: %q", i, maxLineOctets, line)
+				}
+
+				if i > 0 && !strings.HasPrefix(line, " ") {
+					t.Errorf("continuation line %d does not begin with a space: %q", i, line)
+				}
+
+				if !utf8.ValidString(line) {
+					t.Errorf("line %d splits a UTF-8 character: %q", i, line)
+				}
+			}
+
+			if unfolded := strings.ReplaceAll(strings.TrimSuffix(got, lineBreak), lineBreak+" ", ""); unfolded != tt.line {
+				t.Errorf("want unfolded line %q, got %q", tt.line, unfolded)
+			}
+		})
+	}
+}
+
+// TestDuration asserts that reminder durations are formatted as RFC 5545
+// DURATION values.
+func TestDuration(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		value time.Duration
+		want  string
+	}{
+		"days":          {value: 30 * 24 * time.Hour, want: "P30D"},
+		"days hours":    {value: 36 * time.Hour, want: "P1DT12H"},
+		"hours minutes": {value: 90 * time.Minute, want: "PT1H30M"},
+		"zero":          {value: 0, want: "PT0S"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := duration(tt.value); got != tt.want {
+				t.Errorf("want %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
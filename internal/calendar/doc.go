@@ -0,0 +1,11 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-whois
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+// Package calendar provides support for exporting evaluated domain
+// expiration dates as an iCalendar (RFC 5545) calendar suitable for
+// subscription by calendar applications.
+package calendar
@@ -0,0 +1,65 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-whois
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package checker
+
+import (
+	"context"
+	"sync"
+
+	"github.com/atc0005/check-whois/internal/domain"
+)
+
+// Outcome is the outcome of checking the registration data for one domain
+// in a batch of domains.
+type Outcome struct {
+
+	// Domain is the evaluated domain metadata. This is nil if the
+	// registration data could not be retrieved, parsed or evaluated.
+	Domain *domain.Metadata
+
+	// Result is the summary of the check results.
+	Result Result
+
+	// Err records any error encountered while checking the domain.
+	Err error
+}
+
+// CheckAll checks the registration data for the given (ASCII) domain names,
+// limiting the number of lookups performed at the same time to the given
+// concurrency value. Outcomes are returned in the same order as the given
+// domain names.
+func (c *Checker) CheckAll(ctx context.Context, names []string, concurrency int) []Outcome {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	outcomes := make([]Outcome, len(names))
+	semaphore := make(chan struct{}, concurrency)
+
+	var wg sync.WaitGroup
+	for i, name := range names {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			d, result, err := c.Check(ctx, name)
+			outcomes[i] = Outcome{
+				Domain: d,
+				Result: result,
+				Err:    err,
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	return outcomes
+}
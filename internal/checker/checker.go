@@ -0,0 +1,256 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-whois
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package checker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/atc0005/check-whois/internal/domain"
+	"github.com/atc0005/check-whois/internal/lookup"
+
+	"github.com/atc0005/go-nagios"
+)
+
+// ErrFetchFailed indicates that registration data for a domain could not be
+// retrieved.
+var ErrFetchFailed = errors.New("failed to fetch registration data")
+
+// ErrParseFailed indicates that registration data for a domain could not be
+// parsed.
+var ErrParseFailed = errors.New("failed to parse registration data")
+
+// ErrEvaluateFailed indicates that parsed registration data for a domain
+// could not be evaluated.
+var ErrEvaluateFailed = errors.New("failed to evaluate registration data")
+
+// Config is the collection of thresholds used to evaluate the registration
+// data for a domain. Unset thresholds are not evaluated.
+type Config struct {
+
+	// AgeWarning is the threshold evaluated against the days remaining
+	// before domain expiration to determine when a WARNING state is
+	// triggered.
+	AgeWarning domain.Threshold
+
+	// AgeCritical is the threshold evaluated against the days remaining
+	// before domain expiration to determine when a CRITICAL state is
+	// triggered.
+	AgeCritical domain.Threshold
+
+	// UpdatedWarning is the threshold evaluated against the days since the
+	// domain registration data was last updated to determine when a WARNING
+	// state is triggered.
+	UpdatedWarning domain.Threshold
+
+	// UpdatedCritical is the threshold evaluated against the days since the
+	// domain registration data was last updated to determine when a CRITICAL
+	// state is triggered.
+	UpdatedCritical domain.Threshold
+
+	// CreatedWarning is the threshold evaluated against the days since the
+	// domain was created to determine when a WARNING state is triggered.
+	CreatedWarning domain.Threshold
+
+	// CreatedCritical is the threshold evaluated against the days since the
+	// domain was created to determine when a CRITICAL state is triggered.
+	CreatedCritical domain.Threshold
+
+	// ExpectedPrivacy is the collection of registrant privacy modes
+	// permitted for the domain. The registrant privacy mode is not evaluated
+	// if not set.
+	ExpectedPrivacy []domain.PrivacyMode
+
+	// PrivacyMismatchState is the service state used when the registrant
+	// privacy mode does not match any of the expected privacy modes.
+	PrivacyMismatchState nagios.ServiceState
+
+	// GracePeriods provides the number of days the registry keeps a domain
+	// in each registration lifecycle phase following the expiration date.
+	// DefaultGracePeriods is used for public suffixes not listed.
+	GracePeriods domain.GracePeriodTable
+
+	// RequireRegistryLock indicates whether the registry (server) lock
+	// status codes are required to be set for the domain.
+	RequireRegistryLock bool
+
+	// RequireRegistrarLock indicates whether the registrar (client) lock
+	// status codes are required to be set for the domain.
+	RequireRegistrarLock bool
+
+	// MissingLockState is the service state used when required registry or
+	// registrar lock status codes are not set.
+	MissingLockState nagios.ServiceState
+
+	// Clock provides the current time used when evaluating registration
+	// data. The system time is used if not set.
+	Clock domain.Clock
+
+	// DateFormat is the layout and time zone used to display dates.
+	DateFormat domain.DateFormat
+
+	// Metrics is the optional list of performance data metrics generated.
+	// All metrics are generated if not specified.
+	Metrics []domain.Metric
+
+	// Templates is the optional collection of templates used to generate
+	// the one-line summary and report in place of the default wording.
+	Templates *domain.Templates
+}
+
+// Result is the outcome of checking the registration data for a domain.
+type Result struct {
+
+	// Domain is the name of the checked domain.
+	Domain string
+
+	// Raw is the raw registration data retrieved for the domain. This is
+	// empty if the registration data could not be retrieved.
+	Raw lookup.RawResult
+
+	// State is the service state for the evaluated registration data. This
+	// is UNKNOWN if the registration data could not be retrieved, parsed or
+	// evaluated.
+	State nagios.ServiceState
+
+	// Problems is the collection of issues found while evaluating the
+	// registration data (e.g., domain.ErrDomainExpiring).
+	Problems []error
+
+	// Duration is the time taken to check the domain.
+	Duration time.Duration
+}
+
+// Checker retrieves registration data using a Lookup and evaluates it
+// against the configured thresholds.
+type Checker struct {
+
+	// lookup is used to retrieve the raw registration data for a domain.
+	lookup lookup.Lookup
+
+	// config is the collection of thresholds used to evaluate registration
+	// data.
+	config Config
+}
+
+// New creates a new Checker which retrieves registration data using the
+// given Lookup and evaluates it using the given thresholds.
+func New(l lookup.Lookup, config Config) *Checker {
+	return &Checker{
+		lookup: l,
+		config: config,
+	}
+}
+
+// Check retrieves, parses and evaluates the registration data for the given
+// (ASCII) domain name. The evaluated domain metadata is returned along with
+// a summary of the results.
+//
+// The returned error wraps ErrFetchFailed, ErrParseFailed or
+// ErrEvaluateFailed (along with the underlying error) to indicate which step
+// failed.
+func (c *Checker) Check(ctx context.Context, name string) (*domain.Metadata, Result, error) {
+	start := time.Now()
+
+	result := Result{
+		Domain: name,
+		State:  unknownState(),
+	}
+
+	raw, err := c.lookup.Fetch(ctx, name)
+	lookupDuration := time.Since(start)
+	if err != nil {
+		result.Duration = time.Since(start)
+		return nil, result, fmt.Errorf("%w: %w", ErrFetchFailed, err)
+	}
+	result.Raw = raw
+
+	parsed, err := lookup.Parse(raw)
+	if err != nil {
+		result.Duration = time.Since(start)
+		return nil, result, fmt.Errorf("%w: %w", ErrParseFailed, err)
+	}
+
+	d, err := domain.NewDomain(parsed, c.config.AgeWarning, c.config.AgeCritical)
+	if err != nil {
+		result.Duration = time.Since(start)
+		return nil, result, fmt.Errorf("%w: %w", ErrEvaluateFailed, err)
+	}
+
+	d.UpdatedWarningThreshold = c.config.UpdatedWarning
+	d.UpdatedCriticalThreshold = c.config.UpdatedCritical
+	d.CreatedWarningThreshold = c.config.CreatedWarning
+	d.CreatedCriticalThreshold = c.config.CreatedCritical
+	d.ExpectedPrivacy = c.config.ExpectedPrivacy
+	d.PrivacyMismatchState = c.config.PrivacyMismatchState
+	d.GracePeriods = c.config.GracePeriods.For(d.Name)
+	d.RequireRegistryLock = c.config.RequireRegistryLock
+	d.RequireRegistrarLock = c.config.RequireRegistrarLock
+	d.MissingLockState = c.config.MissingLockState
+	d.Clock = c.config.Clock
+	d.DateFormat = c.config.DateFormat
+	d.Metrics = c.config.Metrics
+	d.Lookup = &domain.LookupMetrics{
+		Duration:      lookupDuration,
+		ReferralHops:  raw.ReferralHops,
+		ResponseBytes: len(raw.Data),
+	}
+	d.Templates = c.config.Templates
+
+	result.State = d.ServiceState()
+	result.Problems = Problems(d)
+	result.Duration = time.Since(start)
+
+	return d, result, nil
+}
+
+// Problems provides the collection of issues found for the evaluated domain
+// metadata.
+func Problems(d *domain.Metadata) []error {
+	var problems []error
+
+	switch {
+	case d.IsExpired():
+		problems = append(problems, domain.ErrDomainExpired)
+	case d.IsExpiring():
+		problems = append(problems, domain.ErrDomainExpiring)
+	}
+
+	if d.IsRecentlyUpdated() {
+		problems = append(problems, domain.ErrDomainRecentlyUpdated)
+	}
+
+	if d.IsRecentlyCreated() {
+		problems = append(problems, domain.ErrDomainRecentlyCreated)
+	}
+
+	if d.IsPrivacyMismatch() {
+		problems = append(problems, domain.ErrPrivacyMismatch)
+	}
+
+	if d.IsRegistryLockMissing() {
+		problems = append(problems, domain.ErrRegistryLockMissing)
+	}
+
+	if d.IsRegistrarLockMissing() {
+		problems = append(problems, domain.ErrRegistrarLockMissing)
+	}
+
+	return problems
+}
+
+// unknownState provides the service state used when registration data could
+// not be evaluated.
+func unknownState() nagios.ServiceState {
+	return nagios.ServiceState{
+		Label:    nagios.StateUNKNOWNLabel,
+		ExitCode: nagios.StateUNKNOWNExitCode,
+	}
+}
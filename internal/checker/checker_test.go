@@ -0,0 +1,243 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-whois
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package checker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/atc0005/check-whois/internal/domain"
+	"github.com/atc0005/check-whois/internal/lookup"
+
+	"github.com/atc0005/go-nagios"
+	whoisparser "github.com/likexian/whois-parser"
+)
+
+// staticLookup is a Lookup which provides fixed registration data.
+type staticLookup struct {
+	raw lookup.RawResult
+	err error
+}
+
+// Fetch provides the fixed registration data.
+func (sl staticLookup) Fetch(_ context.Context, _ string) (lookup.RawResult, error) {
+	return sl.raw, sl.err
+}
+
+// whoisResponse provides a minimal WHOIS response for the given domain which
+// expires after the given duration.
+func whoisResponse(name string, expiresIn time.Duration) lookup.RawResult {
+	now := time.Now().UTC()
+
+	return lookup.RawResult{
+		Domain: name,
+		Format: lookup.FormatWHOIS,
+		Data: fmt.Sprintf(
+			"Domain Name: %s\n"+
+				"Registrar: Example Registrar, Inc.\n"+
+				"Updated Date: %s\n"+
+				"Creation Date: %s\n"+
+				"Registry Expiry Date: %s\n"+
+				"Domain Status: clientTransferProhibited\n",
+			name,
+			now.AddDate(0, -1, 0).Format(time.RFC3339),
+			now.AddDate(-10, 0, 0).Format(time.RFC3339),
+			now.Add(expiresIn).Format(time.RFC3339),
+		),
+	}
+}
+
+// TestCheckEvaluatesThresholds asserts that retrieved registration data is
+// evaluated against the configured thresholds.
+func TestCheckEvaluatesThresholds(t *testing.T) {
+	t.Parallel()
+
+	day := 24 * time.Hour
+
+	tests := map[string]struct {
+		expiresIn    time.Duration
+		wantState    string
+		wantProblems []error
+	}{
+		"OK":       {expiresIn: 90 * day, wantState: nagios.StateOKLabel},
+		"WARNING":  {expiresIn: 20 * day, wantState: nagios.StateWARNINGLabel, wantProblems: []error{domain.ErrDomainExpiring}},
+		"CRITICAL": {expiresIn: 10 * day, wantState: nagios.StateCRITICALLabel, wantProblems: []error{domain.ErrDomainExpiring}},
+		"expired":  {expiresIn: -day, wantState: nagios.StateCRITICALLabel, wantProblems: []error{domain.ErrDomainExpired}},
+	}
+
+	var config Config
+	if err := config.AgeWarning.Set("30"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := config.AgeCritical.Set("15"); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			c := New(staticLookup{raw: whoisResponse("example.com", tt.expiresIn)}, config)
+
+			d, result, err := c.Check(context.Background(), "example.com")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if d == nil || d.Name != "example.com" {
+				t.Fatalf("unexpected domain metadata: %+v", d)
+			}
+
+			if result.State.Label != tt.wantState {
+				t.Errorf("want state %s, got %s", tt.wantState, result.State.Label)
+			}
+
+			if len(result.Problems) != len(tt.wantProblems) {
+				t.Fatalf("want problems %v, got %v", tt.wantProblems, result.Problems)
+			}
+
+			for i := range tt.wantProblems {
+				if !errors.Is(result.Problems[i], tt.wantProblems[i]) {
+					t.Errorf("want problem %v, got %v", tt.wantProblems[i], result.Problems[i])
+				}
+			}
+		})
+	}
+}
+
+// TestCheckReportsFailedStep asserts that errors identify the failed step
+// and wrap the underlying error.
+func TestCheckReportsFailedStep(t *testing.T) {
+	t.Parallel()
+
+	errUnreachable := errors.New("server unreachable")
+
+	tests := map[string]struct {
+		lookup     lookup.Lookup
+		wantStep   error
+		wantUnwrap error
+	}{
+		"fetch": {
+			lookup:     staticLookup{err: errUnreachable},
+			wantStep:   ErrFetchFailed,
+			wantUnwrap: errUnreachable,
+		},
+		"parse": {
+			lookup: staticLookup{raw: lookup.RawResult{
+				Format: lookup.FormatWHOIS,
+				Data:   "No match for \"EXAMPLE.COM\".\n",
+			}},
+			wantStep:   ErrParseFailed,
+			wantUnwrap: whoisparser.ErrNotFoundDomain,
+		},
+		"evaluate": {
+			lookup: staticLookup{raw: lookup.RawResult{
+				Format: lookup.FormatWHOIS,
+				Data:   "Domain Name: example.com\nRegistrar: Example Registrar, Inc.\n",
+			}},
+			wantStep:   ErrEvaluateFailed,
+			wantUnwrap: ErrEvaluateFailed,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			d, result, err := New(tt.lookup, Config{}).Check(context.Background(), "example.com")
+
+			if !errors.Is(err, tt.wantStep) || !errors.Is(err, tt.wantUnwrap) {
+				t.Errorf("want error wrapping %v and %v, got %v", tt.wantStep, tt.wantUnwrap, err)
+			}
+
+			if d != nil {
+				t.Errorf("want nil domain metadata, got %+v", d)
+			}
+
+			if result.State.ExitCode != nagios.StateUNKNOWNExitCode {
+				t.Errorf("want UNKNOWN state, got %s", result.State.Label)
+			}
+		})
+	}
+}
+
+// domainLookup is a Lookup which provides registration data for each domain
+// expiring after the duration recorded for that domain. An error is
+// returned for unknown domains.
+type domainLookup map[string]time.Duration
+
+// Fetch provides the registration data for the given domain.
+func (dl domainLookup) Fetch(_ context.Context, name string) (lookup.RawResult, error) {
+	expiresIn, ok := dl[name]
+	if !ok {
+		return lookup.RawResult{}, fmt.Errorf("no registration data for %s", name)
+	}
+
+	return whoisResponse(name, expiresIn), nil
+}
+
+// TestCheckAllPreservesOrder asserts that batch outcomes are returned in
+// the order of the given domain names and record per-domain errors.
+func TestCheckAllPreservesOrder(t *testing.T) {
+	t.Parallel()
+
+	day := 24 * time.Hour
+
+	var config Config
+	if err := config.AgeWarning.Set("30"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := config.AgeCritical.Set("15"); err != nil {
+		t.Fatal(err)
+	}
+
+	c := New(
+		domainLookup{
+			"example.com": 90 * day,
+			"example.net": 20 * day,
+			"example.org": 10 * day,
+		},
+		config,
+	)
+
+	names := []string{"example.org", "missing.com", "example.com", "example.net"}
+	wantStates := []string{
+		nagios.StateCRITICALLabel,
+		nagios.StateUNKNOWNLabel,
+		nagios.StateOKLabel,
+		nagios.StateWARNINGLabel,
+	}
+
+	outcomes := c.CheckAll(context.Background(), names, 2)
+	if len(outcomes) != len(names) {
+		t.Fatalf("want %d outcomes, got %d", len(names), len(outcomes))
+	}
+
+	for i, outcome := range outcomes {
+		if outcome.Result.State.Label != wantStates[i] {
+			t.Errorf("%s: want state %s, got %s", names[i], wantStates[i], outcome.Result.State.Label)
+		}
+
+		if names[i] == "missing.com" {
+			if !errors.Is(outcome.Err, ErrFetchFailed) || outcome.Domain != nil {
+				t.Errorf("%s: want fetch error without domain metadata, got %v", names[i], outcome.Err)
+			}
+
+			continue
+		}
+
+		if outcome.Err != nil || outcome.Domain == nil || outcome.Domain.Name != names[i] {
+			t.Errorf("%s: unexpected outcome: %+v", names[i], outcome)
+		}
+	}
+}
@@ -0,0 +1,10 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-whois
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+// Package checker provides a Checker type used to retrieve, parse and
+// evaluate the registration data for a domain against specified thresholds.
+package checker
@@ -0,0 +1,42 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-whois
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package config
+
+import (
+	"github.com/atc0005/check-whois/internal/checker"
+)
+
+// CheckerConfig provides the (validated) thresholds and evaluation settings
+// used to check domains. Each application uses these settings so that a
+// domain is evaluated the same way regardless of the application used.
+// Settings whose flags are not supported by an application are left unset
+// and are not evaluated.
+func (c Config) CheckerConfig() checker.Config {
+	return checker.Config{
+		AgeWarning:      c.AgeWarning,
+		AgeCritical:     c.AgeCritical,
+		UpdatedWarning:  c.UpdatedWarning,
+		UpdatedCritical: c.UpdatedCritical,
+		CreatedWarning:  c.CreatedWarning,
+		CreatedCritical: c.CreatedCritical,
+
+		ExpectedPrivacy:      c.ExpectedPrivacyModes(),
+		PrivacyMismatchState: c.PrivacyMismatchServiceState(),
+
+		GracePeriods: c.GracePeriodTable(),
+
+		RequireRegistryLock:  c.RequireRegistryLock,
+		RequireRegistrarLock: c.RequireRegistrarLock,
+		MissingLockState:     c.MissingLockServiceState(),
+
+		Clock:      c.Clock(),
+		DateFormat: c.DisplayDateFormat(),
+		Metrics:    c.PerfDataMetrics(),
+		Templates:  c.Templates(),
+	}
+}
@@ -13,8 +13,11 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/rs/zerolog"
+
+	"github.com/atc0005/check-whois/internal/domain"
 )
 
 // Updated via Makefile builds. Setting placeholder value here so that
@@ -55,6 +58,34 @@ func Branding(msg string) func() string {
 	}
 }
 
+// AppType represents the type of application that is being
+// configured/initialized. Not all application types will use the same
+// features and as a result will not accept the same flags. Unless noted
+// otherwise, each of the application types are incompatible with each other,
+// though some flags are common to all types.
+type AppType struct {
+
+	// Plugin represents an application used as a Nagios plugin to monitor
+	// the expiration of a domain's WHOIS records.
+	Plugin bool
+
+	// LookalikePlugin represents an application used as a Nagios plugin to
+	// monitor registration of lookalike (typosquat) permutations of a domain.
+	LookalikePlugin bool
+
+	// Calendar represents an application used to export the expiration
+	// dates of a list of domains as an iCalendar (.ics) calendar.
+	Calendar bool
+
+	// Inventory represents an application used to report the registration
+	// details of a list of domains as an inventory table.
+	Inventory bool
+
+	// Server represents an application used to provide domain registration
+	// details and evaluation results via an HTTP API.
+	Server bool
+}
+
 // Config represents the application configuration as specified via
 // command-line flags.
 type Config struct {
@@ -63,22 +94,131 @@ type Config struct {
 	Log zerolog.Logger
 
 	// Domain is the name of the domain whose WHOIS records will be evaluated.
+	// Internationalized domain names are converted to ASCII (punycode) form
+	// and the name is reduced to the registrable domain during configuration
+	// initialization.
 	Domain string
 
+	// domainInput is the domain name value as originally provided by the
+	// user, prior to normalization.
+	domainInput string
+
 	// RegistrarServer is the optional user-specified server to use for WHOIS
 	// lookups.
 	RegistrarServer string
 
+	// LookupMethod is the method used to retrieve domain registration data
+	// (e.g., WHOIS or RDAP).
+	LookupMethod string
+
+	// LookupFile is the path to a file (or directory of files) containing
+	// previously saved registration data. This is used with the file lookup
+	// method.
+	LookupFile string
+
+	// RDAPServer is the optional user-specified RDAP server base URL to use
+	// for RDAP lookups.
+	RDAPServer string
+
+	// CacheDir is the optional directory used to cache retrieved
+	// registration data across executions.
+	CacheDir string
+
+	// CacheTTL is the maximum age of cached registration data.
+	CacheTTL time.Duration
+
+	// AsOf is the optional date and time used to evaluate domain metadata
+	// instead of the current time (e.g., to determine what state a domain
+	// will be in on a future date). The zero value indicates that the
+	// current time is used.
+	AsOf time.Time
+
+	// asOfInput is the as-of date value as provided by the user, prior to
+	// parsing.
+	asOfInput string
+
+	// Timeout is the overall time allowed for the plugin to complete
+	// registration data lookups.
+	Timeout time.Duration
+
+	// TimeoutState is the service state label (e.g., unknown) used when the
+	// timeout is reached before lookups complete.
+	TimeoutState string
+
+	// MissingExpirationState is the service state label (e.g., unknown) used
+	// when the domain expiration date is missing from the registration data.
+	MissingExpirationState string
+
+	// ExpectedPrivacy is the optional list of registrant privacy modes
+	// (e.g., redacted, proxy or public) permitted for the domain.
+	ExpectedPrivacy multiValueStringFlag
+
+	// PrivacyMismatchState is the service state label (e.g., warning) used
+	// when the registrant privacy mode does not match any of the expected
+	// privacy modes.
+	PrivacyMismatchState string
+
+	// GracePeriods is the optional list of public suffix grace period
+	// lengths (e.g., com=45:30:5) used to determine the registration
+	// lifecycle phase of an expired domain.
+	GracePeriods multiValueStringFlag
+
+	// RequireRegistryLock indicates whether the registry (server) lock
+	// status codes are required to be set for the domain.
+	RequireRegistryLock bool
+
+	// RequireRegistrarLock indicates whether the registrar (client) lock
+	// status codes are required to be set for the domain.
+	RequireRegistrarLock bool
+
+	// MissingLockState is the service state label (e.g., critical) used
+	// when required registry or registrar lock status codes are not set.
+	MissingLockState string
+
+	// ReportLevel is the level of detail (e.g., standard or verbose)
+	// included in the plugin report.
+	ReportLevel string
+
+	// Retries is the number of times a failed lookup is retried.
+	Retries int
+
+	// RetryDelay is the time waited before retrying a failed lookup.
+	RetryDelay time.Duration
+
 	// LoggingLevel is the supported logging level for this application.
 	LoggingLevel string
 
-	// AgeWarning is the number of days remaining before domain expiration
-	// when a WARNING state is triggered.
-	AgeWarning int
+	// AgeWarning is the threshold (number of days, duration or Nagios range)
+	// evaluated against the days remaining before domain expiration to
+	// determine when a WARNING state is triggered.
+	AgeWarning domain.Threshold
 
-	// AgeCritical is the number of days remaining before domain expiration
-	// when a CRITICAL state is triggered.
-	AgeCritical int
+	// AgeCritical is the threshold (number of days, duration or Nagios range)
+	// evaluated against the days remaining before domain expiration to
+	// determine when a CRITICAL state is triggered.
+	AgeCritical domain.Threshold
+
+	// UpdatedWarning is the optional threshold (number of days, duration or
+	// Nagios range) evaluated against the days since the domain WHOIS
+	// metadata was last updated to determine when a WARNING state is
+	// triggered.
+	UpdatedWarning domain.Threshold
+
+	// UpdatedCritical is the optional threshold (number of days, duration or
+	// Nagios range) evaluated against the days since the domain WHOIS
+	// metadata was last updated to determine when a CRITICAL state is
+	// triggered.
+	UpdatedCritical domain.Threshold
+
+	// CreatedWarning is the optional threshold (number of days, duration or
+	// Nagios range) evaluated against the days since the domain was created
+	// to determine when a WARNING state is triggered.
+	CreatedWarning domain.Threshold
+
+	// CreatedCritical is the optional threshold (number of days, duration or
+	// Nagios range) evaluated against the days since the domain was created
+	// to determine when a CRITICAL state is triggered.
+	CreatedCritical domain.Threshold
 
 	// EmitBranding controls whether "generated by" text is included at the
 	// bottom of application output. This output is included in the Nagios
@@ -91,6 +231,133 @@ type Config struct {
 	// should be disabled.
 	DisableReferralLookups bool
 
+	// StrictDomain controls whether domain name input which is not a
+	// registrable domain (e.g., a URL or subdomain) is rejected instead of
+	// being reduced to the registrable domain.
+	StrictDomain bool
+
+	// Permutations is the list of lookalike permutation kinds used to
+	// generate lookalike domain names.
+	Permutations multiValueStringFlag
+
+	// TLDs is the list of public suffixes used for TLD swap lookalike
+	// permutations.
+	TLDs multiValueStringFlag
+
+	// IgnoredDomains is the list of known lookalike domain names (e.g.,
+	// defensive registrations) which are excluded from evaluation.
+	IgnoredDomains multiValueStringFlag
+
+	// Concurrency is the maximum number of WHOIS lookups performed at the
+	// same time.
+	Concurrency int
+
+	// Domains is the list of domain names evaluated by applications which
+	// support multiple domains. Names are converted to the registrable
+	// domain in ASCII (punycode) form during configuration.
+	Domains multiValueStringFlag
+
+	// DomainsFile is the optional path to a file containing domain names
+	// (one per line) evaluated by applications which support multiple
+	// domains.
+	DomainsFile string
+
+	// Output is the optional path to the file where generated output is
+	// written. Output is written to stdout if not specified.
+	Output string
+
+	// CalendarName is the display name of the generated calendar.
+	CalendarName string
+
+	// InventoryFormat is the output format of the generated inventory.
+	InventoryFormat string
+
+	// InventorySort is the column used to sort the generated inventory.
+	InventorySort string
+
+	// ListenAddress is the TCP network address (host:port) the API server
+	// listens on.
+	ListenAddress string
+
+	// ResultCacheTTL is the time evaluation results are reused by the API
+	// server before domains are checked again. Results are not cached if
+	// zero.
+	ResultCacheTTL time.Duration
+
+	// OutputFormat is the output format of the plugin (e.g., nagios or
+	// checkmk).
+	OutputFormat string
+
+	// HostName is the template used to generate the monitoring system host
+	// name for each domain result.
+	HostName string
+
+	// ServiceName is the template used to generate the monitoring system
+	// service name for each domain result.
+	ServiceName string
+
+	// CommandFile is the optional path to the Nagios external command file
+	// (or another named pipe) where passive check results are written.
+	CommandFile string
+
+	// Metrics is the optional list of performance data metrics generated for
+	// each domain. All metrics are generated if not specified.
+	Metrics multiValueStringFlag
+
+	// DateFormat is the named preset (e.g., rfc3339) or Go time layout used
+	// to display dates.
+	DateFormat string
+
+	// DisplayTimeZone is the optional time zone (e.g., America/Chicago or
+	// Local) used to display dates. Dates are displayed in the time zone
+	// provided by the registration data if not specified.
+	DisplayTimeZone string
+
+	// SummaryTemplate is the optional Go template used to generate the
+	// one-line summary in place of the default wording.
+	SummaryTemplate string
+
+	// SummaryTemplateFile is the optional path to a file containing the Go
+	// template used to generate the one-line summary.
+	SummaryTemplateFile string
+
+	// ReportTemplate is the optional Go template used to generate the report
+	// in place of the default report.
+	ReportTemplate string
+
+	// ReportTemplateFile is the optional path to a file containing the Go
+	// template used to generate the report.
+	ReportTemplateFile string
+
+	// TemplateVars is the optional list of key=value pairs (e.g., a ticket
+	// number) made available to the summary and report templates.
+	TemplateVars multiValueStringFlag
+
+	// templates are the summary and report templates parsed from the
+	// SummaryTemplate and ReportTemplate values, or nil if neither template
+	// was specified.
+	templates *domain.Templates
+
+	// WebhookURL is the optional webhook URL notified of domain state
+	// transitions. Notifications are not sent if not specified.
+	WebhookURL string
+
+	// WebhookFormat is the payload format used for webhook notifications.
+	WebhookFormat string
+
+	// WebhookRetries is the number of times a failed webhook notification is
+	// retried.
+	WebhookRetries int
+
+	// WebhookRetryDelay is the time waited before retrying a failed webhook
+	// notification.
+	WebhookRetryDelay time.Duration
+
+	// NotifyStateFile is the optional path to the file used to record the
+	// last notified state of each domain so that state transitions are
+	// tracked across executions.
+	NotifyStateFile string
+
 	// ShowVersion is a flag indicating whether the user opted to display only
 	// the version string and then immediately exit the application.
 	ShowVersion bool
@@ -100,16 +367,20 @@ type Config struct {
 // provided flag and config file values. It is responsible for validating
 // user-provided values and initializing the logging settings used by this
 // application.
-func New() (*Config, error) {
+func New(appType AppType) (*Config, error) {
 	var config Config
 
-	config.handleFlagsConfig()
+	config.handleFlagsConfig(appType)
 
 	if config.ShowVersion {
 		return nil, ErrVersionRequested
 	}
 
-	if err := config.validate(); err != nil {
+	if err := config.normalize(); err != nil {
+		return nil, fmt.Errorf("configuration normalization failed: %w", err)
+	}
+
+	if err := config.validate(appType); err != nil {
 		return nil, fmt.Errorf("configuration validation failed: %w", err)
 	}
 
@@ -121,6 +392,32 @@ func New() (*Config, error) {
 		)
 	}
 
+	config.logNormalization()
+
 	return &config, nil
 
 }
+
+// Clock provides the Clock used to evaluate domain metadata. A fixed clock is
+// provided if an as-of date was specified, otherwise the system clock is
+// used.
+func (c Config) Clock() domain.Clock {
+	if c.AsOf.IsZero() {
+		return domain.SystemClock{}
+	}
+
+	return domain.FixedClock(c.AsOf)
+}
+
+// DisplayDateFormat provides the (validated) layout and time zone used to
+// display dates.
+func (c Config) DisplayDateFormat() domain.DateFormat {
+	// Values are asserted during configuration validation.
+	layout, _ := domain.ParseDateLayout(c.DateFormat)
+	location, _ := domain.ParseDisplayLocation(c.DisplayTimeZone)
+
+	return domain.DateFormat{
+		Layout:   layout,
+		Location: location,
+	}
+}
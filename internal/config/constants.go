@@ -7,34 +7,190 @@
 
 package config
 
+import (
+	"time"
+
+	"github.com/atc0005/check-whois/internal/domain"
+	"github.com/atc0005/check-whois/internal/inventory"
+	"github.com/atc0005/check-whois/internal/notify"
+	"github.com/atc0005/check-whois/internal/output"
+)
+
 const myAppName string = "check-whois"
 const myAppURL string = "https://github.com/atc0005/" + myAppName
 
 const (
-	domainFlagHelp                  string = "The name of the domain whose WHOIS records will be evaluated."
-	registrarServerFlagHelp         string = "The name of the optional domain registrar WHOIS server to use for queries."
-	versionFlagHelp                 string = "Whether to display application version and then immediately exit application."
-	logLevelFlagHelp                string = "Sets log level to one of disabled, panic, fatal, error, warn, info, debug or trace."
-	brandingFlagHelp                string = "Toggles emission of branding details with plugin status details. This output is disabled by default."
-	disableReferralLookupsFlagHelp  string = "Disables WHOIS server referral lookups. Lookups are enabled by default."
-	domainExpireAgeWarningFlagHelp  string = "The number of days remaining before domain expiration when a WARNING state is triggered."
-	domainExpireAgeCriticalFlagHelp string = "The number of days remaining before domain expiration when a CRITICAL state is triggered."
+	domainFlagHelp                   string = "The name of the domain whose WHOIS records will be evaluated. Internationalized domain names may be provided in Unicode or ASCII (punycode) form. URLs and subdomains are reduced to the registrable domain."
+	strictDomainFlagHelp             string = "Rejects domain values which are not registrable domains (e.g., URLs or subdomains) instead of reducing them to the registrable domain."
+	registrarServerFlagHelp          string = "The name of the optional domain registrar WHOIS server to use for queries."
+	versionFlagHelp                  string = "Whether to display application version and then immediately exit application."
+	logLevelFlagHelp                 string = "Sets log level to one of disabled, panic, fatal, error, warn, info, debug or trace."
+	brandingFlagHelp                 string = "Toggles emission of branding details with plugin status details. This output is disabled by default."
+	disableReferralLookupsFlagHelp   string = "Disables WHOIS server referral lookups. Lookups are enabled by default."
+	domainExpireAgeWarningFlagHelp   string = "The number of days (e.g., 30), duration (e.g., 72h, 2w, 3d12h) or Nagios range (in days, e.g., @0:30) remaining before domain expiration when a WARNING state is triggered."
+	domainExpireAgeCriticalFlagHelp  string = "The number of days (e.g., 15), duration (e.g., 72h, 2w, 3d12h) or Nagios range (in days, e.g., @0:15) remaining before domain expiration when a CRITICAL state is triggered."
+	updatedWarningFlagHelp           string = "The optional number of days, duration or Nagios range (in days) since the domain WHOIS metadata was last updated when a WARNING state is triggered (e.g., 7 triggers if updated within the last 7 days)."
+	updatedCriticalFlagHelp          string = "The optional number of days, duration or Nagios range (in days) since the domain WHOIS metadata was last updated when a CRITICAL state is triggered (e.g., 1 triggers if updated within the last day)."
+	createdWarningFlagHelp           string = "The optional number of days, duration or Nagios range (in days) since the domain was created when a WARNING state is triggered (e.g., 30 triggers if created within the last 30 days)."
+	createdCriticalFlagHelp          string = "The optional number of days, duration or Nagios range (in days) since the domain was created when a CRITICAL state is triggered (e.g., 7 triggers if created within the last 7 days)."
+	lookalikeCreatedWarningFlagHelp  string = "The number of days, duration or Nagios range (in days) since a registered lookalike domain was created when a WARNING state is triggered."
+	lookalikeCreatedCriticalFlagHelp string = "The number of days, duration or Nagios range (in days) since a registered lookalike domain was created when a CRITICAL state is triggered."
+	permutationsFlagHelp             string = "Comma-separated list of lookalike permutation kinds to generate. Supported kinds are omission, swap, homoglyph, tld-swap and hyphenation. All kinds are used by default."
+	tldsFlagHelp                     string = "Comma-separated list of public suffixes used for tld-swap lookalike permutations. A default list of common TLDs is used if not specified."
+	ignoredDomainsFlagHelp           string = "Comma-separated list of known lookalike domains (e.g., defensive registrations) to exclude from evaluation."
+	concurrencyFlagHelp              string = "The maximum number of WHOIS lookups performed at the same time."
+	lookupMethodFlagHelp             string = "The method used to retrieve domain registration data. Supported methods are whois, rdap and file."
+	lookupFileFlagHelp               string = "The path to a file (or a directory of files named after each domain) containing previously saved WHOIS or RDAP registration data. Required when using the file lookup method."
+	rdapServerFlagHelp               string = "The base URL of the optional RDAP server to use for all RDAP queries (e.g., https://rdap.verisign.com/com/v1/). The IANA RDAP bootstrap registry is used to find the RDAP server if not specified."
+	cacheDirFlagHelp                 string = "The optional directory used to cache retrieved registration data across executions. Caching is disabled if not specified."
+	cacheTTLFlagHelp                 string = "The maximum age (e.g., 12h) of cached registration data before it is retrieved again."
+	asOfFlagHelp                     string = "The optional date (e.g., 2026-12-24), date and time (e.g., 2026-12-24 17:00) or RFC 3339 timestamp used to evaluate domain metadata instead of the current time. This is useful to determine what state a domain will be in on a future date. Dates without a time zone use the local time zone."
+	timeoutFlagHelp                  string = "The overall time (e.g., 30s) allowed for registration data lookups (including WHOIS server discovery, referral lookups and retries) to complete. This should be lower than the service_check_timeout value used by Nagios."
+	timeoutStateFlagHelp             string = "The state (ok, warning, critical or unknown) returned when the timeout is reached before lookups complete."
+	missingExpirationStateFlagHelp   string = "The state (ok, warning, critical or unknown) returned when the domain expiration date is missing from the registration data or cannot be parsed."
+	reportLevelFlagHelp              string = "The level of detail included in the plugin report. Supported levels are standard and verbose. The verbose level lists every contact block (registrar, registrant, administrative, technical and billing) with redacted values labeled as redacted."
+	expectedPrivacyFlagHelp          string = "Comma-separated list of registrant privacy modes permitted for the domain. Supported modes are redacted, proxy (a known privacy or proxy service) and public. The registrant privacy mode is not evaluated if not specified."
+	privacyMismatchStateFlagHelp     string = "The state (ok, warning, critical or unknown) returned when the registrant privacy mode does not match any of the expected privacy modes."
+	requireRegistryLockFlagHelp      string = "Requires the registry lock status codes (serverTransferProhibited, serverUpdateProhibited and serverDeleteProhibited) to be set for the domain."
+	requireRegistrarLockFlagHelp     string = "Requires the registrar lock status codes (clientTransferProhibited, clientUpdateProhibited and clientDeleteProhibited) to be set for the domain."
+	missingLockStateFlagHelp         string = "The state (ok, warning, critical or unknown) returned when required registry or registrar lock status codes are not set."
+	gracePeriodsFlagHelp             string = "Comma-separated list of grace period lengths (in days) used to determine the registration lifecycle phase of an expired domain, specified as suffix=autoRenew:redemption:pendingDelete (e.g., com=45:30:5,uk=90:0:0). Use * as the suffix to change the default of 45:30:5 for suffixes not listed."
+	domainsFlagHelp                  string = "Comma-separated list of domain names to evaluate. May be combined with the domain and domains-file flags."
+	domainsFileFlagHelp              string = "The path to a file containing domain names to evaluate, one per line. Blank lines and lines beginning with # are ignored."
+	outputFlagHelp                   string = "The path to the file where output is written. Output is written to stdout if not specified."
+	calendarNameFlagHelp             string = "The display name of the generated calendar."
+	calendarAgeWarningFlagHelp       string = "The number of days (e.g., 30) or duration (e.g., 72h, 2w, 3d12h) before domain expiration when a WARNING reminder is triggered. The same value is used to determine the state noted in each event."
+	calendarAgeCriticalFlagHelp      string = "The number of days (e.g., 15) or duration (e.g., 72h, 2w, 3d12h) before domain expiration when a CRITICAL reminder is triggered. The same value is used to determine the state noted in each event."
+	inventoryFormatFlagHelp          string = "The output format of the inventory. Supported formats are table, csv, markdown and html."
+	inventorySortFlagHelp            string = "The column used to sort the inventory. Supported columns are domain, registrar, expires and state."
+	listenAddressFlagHelp            string = "The TCP network address (host:port) the API server listens on."
+	resultCacheTTLFlagHelp           string = "The time (e.g., 15m) evaluation results are reused by the API server before domains are checked again. Set to 0 to disable the result cache."
+	outputFormatFlagHelp             string = "The output format of the plugin. Supported formats are nagios (the default; single domain only), checkmk (local check lines), sensu (Sensu Go event JSON), icinga2 (Icinga 2 API process-check-result JSON) and passive (Nagios PROCESS_SERVICE_CHECK_RESULT external commands). Formats other than nagios emit one result per domain."
+	hostNameFlagHelp                 string = "The Go template (e.g., {{.Domain}}) used to generate the monitoring system host name for each domain result. The Domain and State fields are available."
+	serviceNameFlagHelp              string = "The Go template (e.g., WHOIS {{.Domain}}) used to generate the monitoring system service name for each domain result. The Domain and State fields are available."
+	commandFileFlagHelp              string = "The path to the Nagios external command file (or another named pipe) where passive check results are written when using the passive output format. Results are written to stdout if not specified."
+	perfDataMetricsFlagHelp          string = "Comma-separated list of performance data metrics generated for each domain. Supported metrics are expires, expires_seconds, since_update, since_creation, lock_coverage, lookup_time, referral_hops, response_bytes, nameservers, status_codes and dnssec. All metrics are generated if not specified."
+	dateFormatFlagHelp               string = "The layout used to display dates in reports, threshold descriptions, templates, calendar event descriptions and inventories. Supported presets are default, rfc3339, rfc1123, iso-date and datetime; any other value is used as a Go time layout (e.g., 02 Jan 2006 15:04 MST)."
+	displayTimeZoneFlagHelp          string = "The optional time zone (e.g., America/Chicago, UTC or Local) used to display dates in reports, threshold descriptions, templates, JSON results, calendar event descriptions and inventories. Dates are displayed in the time zone provided by the registration data if not specified."
+	summaryTemplateFlagHelp          string = "The optional Go template (e.g., {{.State}}: {{.Name}} expires in {{.DaysRemaining}} days) used to generate the one-line summary in place of the default wording. The domain metadata fields, computed values (e.g., .DaysRemaining, .HoursRemaining, .State, .Statuses, .Vars) and date helper functions are available."
+	summaryTemplateFileFlagHelp      string = "The path to a file containing the Go template used to generate the one-line summary. May not be used with the summary-template flag."
+	reportTemplateFlagHelp           string = "The optional Go template used to generate the report in place of the default report. The same fields and helper functions as the summary template are available."
+	reportTemplateFileFlagHelp       string = "The path to a file containing the Go template used to generate the report. May not be used with the report-template flag."
+	templateVarFlagHelp              string = "A key=value pair (e.g., ticket=INC0012345) made available to the summary and report templates as .Vars.key. May be repeated or comma-separated."
+	webhookURLFlagHelp               string = "The optional webhook URL notified when the state of a domain changes (e.g., OK to WARNING, WARNING to CRITICAL or recovery to OK)."
+	webhookFormatFlagHelp            string = "The payload format used for webhook notifications. Supported formats are json, teams and slack."
+	webhookRetriesFlagHelp           string = "The number of times a failed webhook notification is retried."
+	webhookRetryDelayFlagHelp        string = "The time (e.g., 5s) waited before retrying a failed webhook notification."
+	notifyStateFileFlagHelp          string = "The path to the file used to record the last notified state of each domain so that state transitions are tracked across executions."
+	retriesFlagHelp                  string = "The number of times a failed lookup is retried (within the overall timeout)."
+	retryDelayFlagHelp               string = "The time (e.g., 2s) waited before retrying a failed lookup."
 )
 
 // Default flag settings if not overridden by user input
 const (
 	defaultDomain                 string = ""
 	defaultRegistrarServer        string = ""
+	defaultLookupMethod           string = LookupMethodWHOIS
+	defaultLookupFile             string = ""
+	defaultRDAPServer             string = ""
+	defaultCacheDir               string = ""
+	defaultAsOf                   string = ""
 	defaultLogLevel               string = "info"
 	defaultDisableReferralLookups bool   = false
+	defaultStrictDomain           bool   = false
 	defaultBranding               bool   = false
 	defaultDisplayVersionAndExit  bool   = false
 
 	// Default WARNING threshold is 30 days
-	defaultDomainExpireAgeWarning int = 30
+	defaultDomainExpireAgeWarning string = "30"
 
 	// Default CRITICAL threshold is 15 days
-	defaultDomainExpireAgeCritical int = 15
+	defaultDomainExpireAgeCritical string = "15"
+
+	// Default lookalike domain WARNING threshold is 30 days since creation
+	defaultLookalikeCreatedWarning string = "30"
+
+	// Default lookalike domain CRITICAL threshold is 7 days since creation
+	defaultLookalikeCreatedCritical string = "7"
+
+	// Default to a small number of concurrent WHOIS lookups to reduce the
+	// risk of hitting WHOIS server rate limits.
+	defaultConcurrency int = 4
+
+	// Default to reusing cached registration data for up to a day.
+	defaultCacheTTL time.Duration = 24 * time.Hour
+
+	// Default to reusing API server evaluation results for a short time so
+	// that repeated requests do not trigger repeated lookups.
+	defaultResultCacheTTL time.Duration = 15 * time.Minute
+
+	// Default to completing lookups before the default Nagios
+	// service_check_timeout of 60 seconds is reached.
+	defaultTimeout time.Duration = 50 * time.Second
+
+	defaultTimeoutState string        = "unknown"
+	defaultRetries      int           = 0
+	defaultRetryDelay   time.Duration = 2 * time.Second
+
+	defaultWebhookRetries    int           = 2
+	defaultWebhookRetryDelay time.Duration = 5 * time.Second
+
+	defaultMissingExpirationState string = "unknown"
+	defaultReportLevel            string = ReportLevelStandard
+	defaultPrivacyMismatchState   string = "warning"
+	defaultDomainsFile            string = ""
+	defaultOutput                 string = ""
+	defaultCalendarName           string = "Domain Expiration"
+	defaultInventoryFormat        string = string(inventory.FormatTable)
+	defaultInventorySort          string = string(inventory.SortDomain)
+	defaultListenAddress          string = "localhost:8080"
+	defaultOutputFormat           string = OutputFormatNagios
+	defaultHostName               string = output.DefaultHostNameTemplate
+	defaultServiceName            string = output.DefaultServiceNameTemplate
+	defaultCommandFile            string = ""
+	defaultDateFormat             string = domain.DateLayoutDefault
+	defaultDisplayTimeZone        string = ""
+	defaultSummaryTemplate        string = ""
+	defaultSummaryTemplateFile    string = ""
+	defaultReportTemplate         string = ""
+	defaultReportTemplateFile     string = ""
+	defaultWebhookURL             string = ""
+	defaultWebhookFormat          string = string(notify.FormatJSON)
+	defaultNotifyStateFile        string = ""
+	defaultMissingLockState       string = "critical"
+	defaultRequireRegistryLock    bool   = false
+	defaultRequireRegistrarLock   bool   = false
+)
+
+const (
+
+	// ReportLevelStandard provides an overview of domain details in the
+	// plugin report.
+	ReportLevelStandard string = "standard"
+
+	// ReportLevelVerbose provides an overview of domain details followed by
+	// every contact block in the plugin report.
+	ReportLevelVerbose string = "verbose"
+)
+
+const (
+
+	// OutputFormatNagios emits Nagios plugin output for a single domain.
+	OutputFormatNagios string = "nagios"
+)
+
+const (
+
+	// LookupMethodWHOIS retrieves registration data using the WHOIS
+	// protocol.
+	LookupMethodWHOIS string = "whois"
+
+	// LookupMethodRDAP retrieves registration data using the Registration
+	// Data Access Protocol.
+	LookupMethodRDAP string = "rdap"
+
+	// LookupMethodFile reads previously saved registration data from a file.
+	LookupMethodFile string = "file"
 )
 
 const (
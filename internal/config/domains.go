@@ -0,0 +1,27 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-whois
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package config
+
+// DomainList provides the (normalized) domain names evaluated by
+// applications which support multiple domains. The single domain name (if
+// specified) is listed first.
+func (c Config) DomainList() []string {
+	names := make([]string, 0, len(c.Domains)+1)
+
+	if c.Domain != "" {
+		names = append(names, c.Domain)
+	}
+
+	for _, name := range c.Domains {
+		if name != c.Domain {
+			names = append(names, name)
+		}
+	}
+
+	return names
+}
@@ -7,38 +7,252 @@
 
 package config
 
-import "flag"
+import (
+	"flag"
+	"strings"
+
+	"github.com/atc0005/check-whois/internal/lookalike"
+)
+
+// multiValueStringFlag is a custom type that satisfies the flag.Value
+// interface in order to accept multiple string values for some of our flags.
+type multiValueStringFlag []string
+
+// String returns a comma separated string consisting of all slice elements.
+func (mvs *multiValueStringFlag) String() string {
+
+	// From the `flag` package docs:
+	// "The flag package may call the String method with a zero-valued
+	// receiver, such as a nil pointer."
+	if mvs == nil {
+		return ""
+	}
+
+	return strings.Join(*mvs, ", ")
+}
+
+// Set is called once by the flag package, in command line order, for each
+// flag present.
+func (mvs *multiValueStringFlag) Set(value string) error {
+
+	// split comma-separated string into multiple values, toss whitespace
+	items := strings.Split(value, ",")
+	for index, item := range items {
+		items[index] = strings.TrimSpace(item)
+	}
+
+	// add them to the collection, skipping empty values
+	for _, item := range items {
+		if item == "" {
+			continue
+		}
+		*mvs = append(*mvs, item)
+	}
+
+	return nil
+}
 
 // handleFlagsConfig wraps flag setup code into a bundle for potential ease of
 // use and future testability
-func (c *Config) handleFlagsConfig() {
+func (c *Config) handleFlagsConfig(appType AppType) {
 
-	flag.BoolVar(&c.EmitBranding, "branding", defaultBranding, brandingFlagHelp)
+	// Flags common to all application types.
 
 	flag.BoolVar(&c.DisableReferralLookups, "disable-ref-lookups", defaultDisableReferralLookups, disableReferralLookupsFlagHelp)
 
-	flag.IntVar(&c.AgeWarning, "w", defaultDomainExpireAgeWarning, domainExpireAgeWarningFlagHelp)
-	flag.IntVar(&c.AgeWarning, "age-warning", defaultDomainExpireAgeWarning, domainExpireAgeWarningFlagHelp)
-
-	flag.IntVar(&c.AgeCritical, "c", defaultDomainExpireAgeCritical, domainExpireAgeCriticalFlagHelp)
-	flag.IntVar(&c.AgeCritical, "age-critical", defaultDomainExpireAgeCritical, domainExpireAgeCriticalFlagHelp)
-
 	flag.StringVar(&c.LoggingLevel, "ll", defaultLogLevel, logLevelFlagHelp)
 	flag.StringVar(&c.LoggingLevel, "log-level", defaultLogLevel, logLevelFlagHelp)
 
 	flag.StringVar(&c.Domain, "d", defaultDomain, domainFlagHelp)
 	flag.StringVar(&c.Domain, "domain", defaultDomain, domainFlagHelp)
 
+	flag.BoolVar(&c.StrictDomain, "strict-domain", defaultStrictDomain, strictDomainFlagHelp)
+
 	flag.StringVar(&c.RegistrarServer, "s", defaultRegistrarServer, registrarServerFlagHelp)
 	flag.StringVar(&c.RegistrarServer, "server", defaultRegistrarServer, registrarServerFlagHelp)
 
+	flag.StringVar(&c.LookupMethod, "lookup", defaultLookupMethod, lookupMethodFlagHelp)
+	flag.StringVar(&c.LookupFile, "lookup-file", defaultLookupFile, lookupFileFlagHelp)
+	flag.StringVar(&c.RDAPServer, "rdap-server", defaultRDAPServer, rdapServerFlagHelp)
+
+	flag.StringVar(&c.CacheDir, "cache-dir", defaultCacheDir, cacheDirFlagHelp)
+	flag.DurationVar(&c.CacheTTL, "cache-ttl", defaultCacheTTL, cacheTTLFlagHelp)
+
+	flag.StringVar(&c.asOfInput, "as-of", defaultAsOf, asOfFlagHelp)
+
+	flag.StringVar(&c.DateFormat, "date-format", defaultDateFormat, dateFormatFlagHelp)
+	flag.StringVar(&c.DisplayTimeZone, "display-tz", defaultDisplayTimeZone, displayTimeZoneFlagHelp)
+
+	flag.DurationVar(&c.Timeout, "t", defaultTimeout, timeoutFlagHelp)
+	flag.DurationVar(&c.Timeout, "timeout", defaultTimeout, timeoutFlagHelp)
+	flag.StringVar(&c.TimeoutState, "timeout-state", defaultTimeoutState, timeoutStateFlagHelp)
+
+	flag.IntVar(&c.Retries, "retries", defaultRetries, retriesFlagHelp)
+	flag.DurationVar(&c.RetryDelay, "retry-delay", defaultRetryDelay, retryDelayFlagHelp)
+
 	flag.BoolVar(&c.ShowVersion, "v", defaultDisplayVersionAndExit, versionFlagHelp)
 	flag.BoolVar(&c.ShowVersion, "version", defaultDisplayVersionAndExit, versionFlagHelp)
 
+	switch {
+	// The API server evaluates domains in the same way as the plugin.
+	case appType.Plugin || appType.Server:
+
+		if appType.Plugin {
+			flag.BoolVar(&c.EmitBranding, "branding", defaultBranding, brandingFlagHelp)
+
+			// Multiple domains are supported by the monitoring system
+			// native output formats.
+			flag.StringVar(&c.OutputFormat, "output-format", defaultOutputFormat, outputFormatFlagHelp)
+			flag.StringVar(&c.HostName, "host-name", defaultHostName, hostNameFlagHelp)
+			flag.StringVar(&c.ServiceName, "service-name", defaultServiceName, serviceNameFlagHelp)
+			flag.StringVar(&c.CommandFile, "command-file", defaultCommandFile, commandFileFlagHelp)
+			flag.Var(&c.Domains, "domains", domainsFlagHelp)
+			flag.StringVar(&c.DomainsFile, "domains-file", defaultDomainsFile, domainsFileFlagHelp)
+			flag.IntVar(&c.Concurrency, "concurrency", defaultConcurrency, concurrencyFlagHelp)
+		}
+
+		// Apply default threshold values before registering flags so that
+		// they are reflected in the help output.
+		_ = c.AgeWarning.Set(defaultDomainExpireAgeWarning)
+		_ = c.AgeCritical.Set(defaultDomainExpireAgeCritical)
+
+		flag.Var(&c.AgeWarning, "w", domainExpireAgeWarningFlagHelp)
+		flag.Var(&c.AgeWarning, "age-warning", domainExpireAgeWarningFlagHelp)
+
+		flag.Var(&c.AgeCritical, "c", domainExpireAgeCriticalFlagHelp)
+		flag.Var(&c.AgeCritical, "age-critical", domainExpireAgeCriticalFlagHelp)
+
+		flag.StringVar(&c.MissingExpirationState, "missing-expiration-state", defaultMissingExpirationState, missingExpirationStateFlagHelp)
+		flag.StringVar(&c.ReportLevel, "report-level", defaultReportLevel, reportLevelFlagHelp)
+
+		flag.Var(&c.Metrics, "perfdata-metrics", perfDataMetricsFlagHelp)
+
+		flag.StringVar(&c.SummaryTemplate, "summary-template", defaultSummaryTemplate, summaryTemplateFlagHelp)
+		flag.StringVar(&c.SummaryTemplateFile, "summary-template-file", defaultSummaryTemplateFile, summaryTemplateFileFlagHelp)
+		flag.StringVar(&c.ReportTemplate, "report-template", defaultReportTemplate, reportTemplateFlagHelp)
+		flag.StringVar(&c.ReportTemplateFile, "report-template-file", defaultReportTemplateFile, reportTemplateFileFlagHelp)
+		flag.Var(&c.TemplateVars, "template-var", templateVarFlagHelp)
+
+	case appType.LookalikePlugin:
+
+		flag.BoolVar(&c.EmitBranding, "branding", defaultBranding, brandingFlagHelp)
+
+		// Lookalike domains are evaluated using required created date
+		// thresholds.
+		_ = c.CreatedWarning.Set(defaultLookalikeCreatedWarning)
+		_ = c.CreatedCritical.Set(defaultLookalikeCreatedCritical)
+
+		flag.Var(&c.CreatedWarning, "w", lookalikeCreatedWarningFlagHelp)
+		flag.Var(&c.CreatedWarning, "created-warning", lookalikeCreatedWarningFlagHelp)
+
+		flag.Var(&c.CreatedCritical, "c", lookalikeCreatedCriticalFlagHelp)
+		flag.Var(&c.CreatedCritical, "created-critical", lookalikeCreatedCriticalFlagHelp)
+
+		flag.Var(&c.Permutations, "permutations", permutationsFlagHelp)
+		flag.Var(&c.TLDs, "tlds", tldsFlagHelp)
+		flag.Var(&c.IgnoredDomains, "ignore", ignoredDomainsFlagHelp)
+
+		flag.IntVar(&c.Concurrency, "concurrency", defaultConcurrency, concurrencyFlagHelp)
+	}
+
+	if appType.Calendar {
+		_ = c.AgeWarning.Set(defaultDomainExpireAgeWarning)
+		_ = c.AgeCritical.Set(defaultDomainExpireAgeCritical)
+
+		flag.Var(&c.AgeWarning, "w", calendarAgeWarningFlagHelp)
+		flag.Var(&c.AgeWarning, "age-warning", calendarAgeWarningFlagHelp)
+
+		flag.Var(&c.AgeCritical, "c", calendarAgeCriticalFlagHelp)
+		flag.Var(&c.AgeCritical, "age-critical", calendarAgeCriticalFlagHelp)
+
+		flag.Var(&c.Domains, "domains", domainsFlagHelp)
+		flag.StringVar(&c.DomainsFile, "domains-file", defaultDomainsFile, domainsFileFlagHelp)
+
+		flag.IntVar(&c.Concurrency, "concurrency", defaultConcurrency, concurrencyFlagHelp)
+
+		flag.StringVar(&c.Output, "o", defaultOutput, outputFlagHelp)
+		flag.StringVar(&c.Output, "output", defaultOutput, outputFlagHelp)
+		flag.StringVar(&c.CalendarName, "calendar-name", defaultCalendarName, calendarNameFlagHelp)
+	}
+
+	if appType.Inventory {
+		_ = c.AgeWarning.Set(defaultDomainExpireAgeWarning)
+		_ = c.AgeCritical.Set(defaultDomainExpireAgeCritical)
+
+		flag.Var(&c.AgeWarning, "w", domainExpireAgeWarningFlagHelp)
+		flag.Var(&c.AgeWarning, "age-warning", domainExpireAgeWarningFlagHelp)
+
+		flag.Var(&c.AgeCritical, "c", domainExpireAgeCriticalFlagHelp)
+		flag.Var(&c.AgeCritical, "age-critical", domainExpireAgeCriticalFlagHelp)
+
+		flag.Var(&c.Domains, "domains", domainsFlagHelp)
+		flag.StringVar(&c.DomainsFile, "domains-file", defaultDomainsFile, domainsFileFlagHelp)
+
+		flag.IntVar(&c.Concurrency, "concurrency", defaultConcurrency, concurrencyFlagHelp)
+
+		flag.StringVar(&c.Output, "o", defaultOutput, outputFlagHelp)
+		flag.StringVar(&c.Output, "output", defaultOutput, outputFlagHelp)
+		flag.StringVar(&c.InventoryFormat, "f", defaultInventoryFormat, inventoryFormatFlagHelp)
+		flag.StringVar(&c.InventoryFormat, "format", defaultInventoryFormat, inventoryFormatFlagHelp)
+		flag.StringVar(&c.InventorySort, "sort", defaultInventorySort, inventorySortFlagHelp)
+	}
+
+	// The inventory evaluates and reports the state of each domain in the
+	// same way as the plugin and API server.
+	if appType.Plugin || appType.Inventory || appType.Server {
+		flag.Var(&c.UpdatedWarning, "updated-warning", updatedWarningFlagHelp)
+		flag.Var(&c.UpdatedCritical, "updated-critical", updatedCriticalFlagHelp)
+
+		flag.Var(&c.CreatedWarning, "created-warning", createdWarningFlagHelp)
+		flag.Var(&c.CreatedCritical, "created-critical", createdCriticalFlagHelp)
+
+		flag.Var(&c.ExpectedPrivacy, "expected-privacy", expectedPrivacyFlagHelp)
+		flag.StringVar(&c.PrivacyMismatchState, "privacy-mismatch-state", defaultPrivacyMismatchState, privacyMismatchStateFlagHelp)
+		flag.Var(&c.GracePeriods, "grace-periods", gracePeriodsFlagHelp)
+		flag.BoolVar(&c.RequireRegistryLock, "require-registry-lock", defaultRequireRegistryLock, requireRegistryLockFlagHelp)
+		flag.BoolVar(&c.RequireRegistrarLock, "require-registrar-lock", defaultRequireRegistrarLock, requireRegistrarLockFlagHelp)
+		flag.StringVar(&c.MissingLockState, "missing-lock-state", defaultMissingLockState, missingLockStateFlagHelp)
+
+		flag.StringVar(&c.WebhookURL, "webhook-url", defaultWebhookURL, webhookURLFlagHelp)
+		flag.StringVar(&c.WebhookFormat, "webhook-format", defaultWebhookFormat, webhookFormatFlagHelp)
+		flag.IntVar(&c.WebhookRetries, "webhook-retries", defaultWebhookRetries, webhookRetriesFlagHelp)
+		flag.DurationVar(&c.WebhookRetryDelay, "webhook-retry-delay", defaultWebhookRetryDelay, webhookRetryDelayFlagHelp)
+		flag.StringVar(&c.NotifyStateFile, "notify-state-file", defaultNotifyStateFile, notifyStateFileFlagHelp)
+	}
+
+	if appType.Server {
+		flag.StringVar(&c.ListenAddress, "listen", defaultListenAddress, listenAddressFlagHelp)
+		flag.IntVar(&c.Concurrency, "concurrency", defaultConcurrency, concurrencyFlagHelp)
+		flag.DurationVar(&c.ResultCacheTTL, "result-cache-ttl", defaultResultCacheTTL, resultCacheTTLFlagHelp)
+	}
+
 	// Allow our function to override the default Help output
 	flag.Usage = Usage
 
 	// parse flag definitions from the argument list
 	flag.Parse()
 
+	// Apply defaults for multi-value flags not specified by the user.
+	if appType.LookalikePlugin {
+		if len(c.Permutations) == 0 {
+			c.Permutations = defaultPermutations()
+		}
+
+		if len(c.TLDs) == 0 {
+			c.TLDs = append(c.TLDs, lookalike.DefaultTLDs...)
+		}
+	}
+
+}
+
+// defaultPermutations provides the default collection of lookalike
+// permutation kinds; all supported kinds are used by default.
+func defaultPermutations() multiValueStringFlag {
+	kinds := lookalike.SupportedKinds()
+	permutations := make(multiValueStringFlag, 0, len(kinds))
+	for _, kind := range kinds {
+		permutations = append(permutations, string(kind))
+	}
+
+	return permutations
 }
@@ -0,0 +1,53 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-whois
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/atc0005/check-whois/internal/domain"
+)
+
+// parseGracePeriods parses the public suffix and grace period lengths from
+// the given suffix=autoRenew:redemption:pendingDelete entry.
+func parseGracePeriods(entry string) (string, domain.GracePeriods, error) {
+	suffix, lengths, found := strings.Cut(entry, "=")
+	suffix = strings.ToLower(strings.Trim(strings.TrimSpace(suffix), "."))
+	if !found || suffix == "" {
+		return "", domain.GracePeriods{}, fmt.Errorf(
+			"invalid grace periods %q; expected suffix=autoRenew:redemption:pendingDelete (e.g., com=45:30:5)",
+			entry,
+		)
+	}
+
+	periods, err := domain.ParseGracePeriods(lengths)
+	if err != nil {
+		return "", domain.GracePeriods{}, fmt.Errorf(
+			"invalid grace periods for %q: %w",
+			suffix,
+			err,
+		)
+	}
+
+	return suffix, periods, nil
+}
+
+// GracePeriodTable provides the (validated) grace period lengths specified
+// for each public suffix.
+func (c Config) GracePeriodTable() domain.GracePeriodTable {
+	table := make(domain.GracePeriodTable, len(c.GracePeriods))
+
+	for _, entry := range c.GracePeriods {
+		if suffix, periods, err := parseGracePeriods(entry); err == nil {
+			table[suffix] = periods
+		}
+	}
+
+	return table
+}
@@ -0,0 +1,41 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-whois
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package config
+
+import (
+	"strings"
+
+	"github.com/atc0005/check-whois/internal/lookup"
+)
+
+// Lookup provides the Lookup used to retrieve domain registration data as
+// specified by the lookup method, server and cache settings.
+func (c Config) Lookup() lookup.Lookup {
+	var l lookup.Lookup
+
+	switch strings.ToLower(c.LookupMethod) {
+	case LookupMethodRDAP:
+		l = lookup.NewRDAP(c.RDAPServer)
+	case LookupMethodFile:
+		l = lookup.NewFile(c.LookupFile)
+	default:
+		l = lookup.NewWHOIS(c.RegistrarServer, c.DisableReferralLookups)
+	}
+
+	if c.Retries > 0 {
+		l = lookup.NewRetry(l, c.Retries, c.RetryDelay)
+	}
+
+	// There is no benefit to caching registration data already read from
+	// a file.
+	if c.CacheDir != "" && !strings.EqualFold(c.LookupMethod, LookupMethodFile) {
+		l = lookup.NewCache(l, c.CacheTTL, c.CacheDir)
+	}
+
+	return l
+}
@@ -0,0 +1,251 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-whois
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/atc0005/check-whois/internal/domain"
+)
+
+// asOfLayouts is the list of layouts (in order of preference) used to parse
+// the as-of date value.
+var asOfLayouts = []string{
+	time.RFC3339,
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02T15:04",
+	"2006-01-02",
+}
+
+// normalize converts user-provided values into the form expected by later
+// validation and use. An error is returned if a value cannot be normalized.
+func (c *Config) normalize() error {
+	if err := c.normalizeAsOf(); err != nil {
+		return err
+	}
+
+	if err := c.normalizeDomain(); err != nil {
+		return err
+	}
+
+	if err := c.normalizeDomains(); err != nil {
+		return err
+	}
+
+	return c.normalizeTemplates()
+}
+
+// normalizeAsOf parses the optional as-of date value.
+func (c *Config) normalizeAsOf() error {
+	input := strings.TrimSpace(c.asOfInput)
+	if input == "" {
+		return nil
+	}
+
+	for _, layout := range asOfLayouts {
+		if asOf, err := time.ParseInLocation(layout, input, time.Local); err == nil {
+			c.AsOf = asOf
+			return nil
+		}
+	}
+
+	return fmt.Errorf(
+		"invalid as-of date %q; expected a date (YYYY-MM-DD), date and time (YYYY-MM-DD HH:MM) or RFC 3339 timestamp",
+		c.asOfInput,
+	)
+}
+
+// normalizeDomain converts the user-provided domain name into the
+// registrable domain in ASCII (punycode) form.
+func (c *Config) normalizeDomain() error {
+
+	// Validation handles asserting that a domain name was provided.
+	if c.Domain == "" {
+		return nil
+	}
+
+	// Record the original value so that any changes can be logged once
+	// logging is configured.
+	c.domainInput = c.Domain
+
+	name, err := c.NormalizeDomainName(c.Domain)
+	if err != nil {
+		return err
+	}
+
+	c.Domain = name
+
+	return nil
+
+}
+
+// normalizeDomains reads the optional file of domain names and converts the
+// user-provided list of domain names into registrable domains in ASCII
+// (punycode) form. Duplicate names are removed.
+func (c *Config) normalizeDomains() error {
+	names := []string(c.Domains)
+
+	if c.DomainsFile != "" {
+		data, err := os.ReadFile(filepath.Clean(c.DomainsFile))
+		if err != nil {
+			return fmt.Errorf("failed to read domains file: %w", err)
+		}
+
+		for _, line := range strings.Split(string(data), "\n") {
+			line = strings.TrimSpace(line)
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+
+			names = append(names, line)
+		}
+	}
+
+	if len(names) == 0 {
+		return nil
+	}
+
+	seen := make(map[string]struct{}, len(names))
+	normalized := make(multiValueStringFlag, 0, len(names))
+
+	for _, input := range names {
+		name, err := c.NormalizeDomainName(input)
+		if err != nil {
+			return err
+		}
+
+		if _, ok := seen[name]; ok {
+			continue
+		}
+
+		seen[name] = struct{}{}
+		normalized = append(normalized, name)
+	}
+
+	c.Domains = normalized
+
+	return nil
+}
+
+// normalizeTemplates reads the optional summary and report template files
+// and parses the summary and report templates. A template may be provided
+// inline or from a file, but not both.
+func (c *Config) normalizeTemplates() error {
+	templates := []struct {
+		kind     string
+		inline   *string
+		filename string
+	}{
+		{kind: "summary", inline: &c.SummaryTemplate, filename: c.SummaryTemplateFile},
+		{kind: "report", inline: &c.ReportTemplate, filename: c.ReportTemplateFile},
+	}
+
+	for _, tmpl := range templates {
+		if tmpl.filename == "" {
+			continue
+		}
+
+		if *tmpl.inline != "" {
+			return fmt.Errorf(
+				"both %s template and %s template file specified; only one may be used",
+				tmpl.kind,
+				tmpl.kind,
+			)
+		}
+
+		data, err := os.ReadFile(filepath.Clean(tmpl.filename))
+		if err != nil {
+			return fmt.Errorf("failed to read %s template file: %w", tmpl.kind, err)
+		}
+
+		*tmpl.inline = string(data)
+	}
+
+	if c.SummaryTemplate == "" && c.ReportTemplate == "" {
+		return nil
+	}
+
+	parsed, err := domain.ParseTemplates(c.SummaryTemplate, c.ReportTemplate, c.templateVars())
+	if err != nil {
+		return fmt.Errorf("invalid template: %w", err)
+	}
+
+	c.templates = parsed
+
+	return nil
+}
+
+// NormalizeDomainName converts the given user-provided domain name into the
+// registrable domain in ASCII (punycode) form. Domain names which are not
+// registrable domains are rejected if strict handling was requested.
+func (c Config) NormalizeDomainName(input string) (string, error) {
+
+	// Strip any scheme, port, path, etc. from URL input.
+	host := domain.HostFromInput(input)
+
+	if c.StrictDomain && host != strings.TrimSpace(input) {
+		return "", fmt.Errorf(
+			"domain %q is not a bare domain name: %w",
+			input,
+			domain.ErrNotRegistrableDomain,
+		)
+	}
+
+	// Internationalized domain names are converted to ASCII (punycode) form
+	// before use in WHOIS queries.
+	asciiName, err := domain.ASCIIName(host)
+	if err != nil {
+		return "", fmt.Errorf(
+			"failed to normalize domain name %q: %w",
+			input,
+			err,
+		)
+	}
+
+	registrableName, err := domain.RegistrableDomain(asciiName)
+	switch {
+	case err != nil && c.StrictDomain:
+		return "", fmt.Errorf(
+			"failed to normalize domain name %q: %w",
+			input,
+			err,
+		)
+
+	// Use the name as-is if we are unable to determine the registrable
+	// domain and the sysadmin has not requested strict handling.
+	case err != nil:
+		registrableName = asciiName
+
+	case c.StrictDomain && registrableName != asciiName:
+		return "", fmt.Errorf(
+			"domain %q is not a registrable domain (registrable domain is %q): %w",
+			input,
+			registrableName,
+			domain.ErrNotRegistrableDomain,
+		)
+	}
+
+	return registrableName, nil
+
+}
+
+// logNormalization records any changes made to user-provided values during
+// normalization. This is intended to be called once logging is configured.
+func (c Config) logNormalization() {
+	if c.domainInput != "" && c.domainInput != c.Domain {
+		c.Log.Debug().
+			Str("domain_input", c.domainInput).
+			Str("domain", c.Domain).
+			Msg("normalized domain name")
+	}
+}
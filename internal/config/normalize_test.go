@@ -0,0 +1,202 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-whois
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/atc0005/check-whois/internal/domain"
+)
+
+// TestNormalizeDomainName asserts that user-provided domain names are
+// reduced to the registrable domain in ASCII form and that names which are
+// not registrable domains are rejected when strict handling is requested.
+func TestNormalizeDomainName(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		input   string
+		strict  bool
+		want    string
+		wantErr error
+	}{
+		"bare domain": {
+			input: "example.com",
+			want:  "example.com",
+		},
+		"surrounding whitespace": {
+			input: "  example.com  ",
+			want:  "example.com",
+		},
+		"trailing dot": {
+			input: "example.com.",
+			want:  "example.com",
+		},
+		"subdomain": {
+			input: "www.example.co.uk",
+			want:  "example.co.uk",
+		},
+		"url": {
+			input: "https://user@www.example.com:8443/path?query#fragment",
+			want:  "example.com",
+		},
+		"internationalized domain": {
+			input: "bücher.de",
+			want:  "xn--bcher-kva.de",
+		},
+		"public suffix": {
+			input: "co.uk",
+			want:  "co.uk",
+		},
+		"empty": {
+			input:   " ",
+			wantErr: domain.ErrInvalidDomainName,
+		},
+		"strict bare domain": {
+			input:  "example.com",
+			strict: true,
+			want:   "example.com",
+		},
+		"strict internationalized domain": {
+			input:  "bücher.de",
+			strict: true,
+			want:   "xn--bcher-kva.de",
+		},
+		"strict subdomain": {
+			input:   "www.example.co.uk",
+			strict:  true,
+			wantErr: domain.ErrNotRegistrableDomain,
+		},
+		"strict url": {
+			input:   "https://example.com/",
+			strict:  true,
+			wantErr: domain.ErrNotRegistrableDomain,
+		},
+		"strict public suffix": {
+			input:   "co.uk",
+			strict:  true,
+			wantErr: domain.ErrNotRegistrableDomain,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			c := Config{StrictDomain: tt.strict}
+
+			got, err := c.NormalizeDomainName(tt.input)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("want error %v, got %v", tt.wantErr, err)
+				}
+
+				// The error identifies the domain name which could not be
+				// normalized.
+				if !strings.Contains(err.Error(), strconv.Quote(tt.input)) {
+					t.Errorf("want error naming %q, got %v", tt.input, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got != tt.want {
+				t.Errorf("want %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+// TestNormalizeDomains asserts that domain names provided by flag and by
+// file are normalized, that comments and blank lines in the file are
+// ignored and that duplicate names are removed.
+func TestNormalizeDomains(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		domains []string
+		file    string
+		want    []string
+	}{
+		"flag": {
+			domains: []string{"example.com", "www.example.com", "example.net"},
+			want:    []string{"example.com", "example.net"},
+		},
+		"file": {
+			file: "# registrations\n\nexample.com\r\n  EXAMPLE.COM  \nhttps://www.example.net/\nexample.net\n",
+			want: []string{"example.com", "example.net"},
+		},
+		"flag and file": {
+			domains: []string{"example.org", "example.com"},
+			file:    "example.com\nexample.org\nexample.net\n",
+			want:    []string{"example.org", "example.com", "example.net"},
+		},
+		"comments only": {
+			file: "# example.com\n\n",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			c := Config{Domains: tt.domains}
+
+			if tt.file != "" {
+				c.DomainsFile = filepath.Join(t.TempDir(), "domains.txt")
+				if err := os.WriteFile(c.DomainsFile, []byte(tt.file), 0o600); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if err := c.normalizeDomains(); err != nil {
+				t.Fatal(err)
+			}
+
+			if got := []string(c.Domains); !slices.Equal(got, tt.want) {
+				t.Errorf("want %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+// TestNormalizeDomainsErrors asserts that a missing domains file and domain
+// names rejected by strict handling are reported.
+func TestNormalizeDomainsErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]Config{
+		"missing file": {
+			DomainsFile: filepath.Join(t.TempDir(), "missing.txt"),
+		},
+		"strict subdomain": {
+			Domains:      []string{"example.com", "www.example.net"},
+			StrictDomain: true,
+		},
+	}
+
+	for name, c := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if err := c.normalizeDomains(); err == nil {
+				t.Errorf("want error, got domains %q", c.Domains)
+			}
+		})
+	}
+}
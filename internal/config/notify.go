@@ -0,0 +1,46 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-whois
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package config
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/atc0005/check-whois/internal/notify"
+)
+
+// Notifier provides the Notifier used to send webhook notifications of
+// domain state transitions as specified by the webhook and notification
+// state file settings. A nil Notifier is returned if a webhook URL is not
+// specified.
+func (c Config) Notifier() (*notify.Notifier, error) {
+	if c.WebhookURL == "" {
+		return nil, nil
+	}
+
+	format, err := notify.ParseFormat(c.WebhookFormat)
+	if err != nil {
+		return nil, err
+	}
+
+	tracker, err := notify.NewTracker(c.NotifyStateFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load notification states: %w", err)
+	}
+
+	webhook := &notify.Webhook{
+		URL:        c.WebhookURL,
+		Format:     format,
+		Footer:     Branding("Notification generated by ")(),
+		Retries:    c.WebhookRetries,
+		RetryDelay: c.WebhookRetryDelay,
+		Client:     &http.Client{Timeout: c.Timeout},
+	}
+
+	return notify.New(tracker, webhook), nil
+}
@@ -0,0 +1,34 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-whois
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package config
+
+import (
+	"github.com/atc0005/check-whois/internal/output"
+)
+
+// OutputOptions provides the collection of settings used to describe check
+// results in the same way as the plugin.
+func (c Config) OutputOptions() output.Options {
+	return output.Options{
+		Timeout:                c.Timeout,
+		TimeoutState:           c.TimeoutServiceState(),
+		MissingExpirationState: c.MissingExpirationServiceState(),
+		VerboseReport:          c.VerboseReport(),
+		DateFormat:             c.DisplayDateFormat(),
+	}
+}
+
+// OutputNames provides the monitoring system host and service names used
+// for each domain result as specified by the host and service name
+// templates.
+func (c Config) OutputNames() output.Names {
+	// Templates are asserted during configuration validation.
+	names, _ := output.ParseNames(c.HostName, c.ServiceName)
+
+	return names
+}
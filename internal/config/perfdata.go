@@ -0,0 +1,26 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-whois
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package config
+
+import (
+	"github.com/atc0005/check-whois/internal/domain"
+)
+
+// PerfDataMetrics provides the (validated) performance data metrics
+// generated for each domain. All metrics are generated if none were
+// specified.
+func (c Config) PerfDataMetrics() []domain.Metric {
+	metrics := make([]domain.Metric, 0, len(c.Metrics))
+	for _, value := range c.Metrics {
+		if metric, err := domain.ParseMetric(value); err == nil {
+			metrics = append(metrics, metric)
+		}
+	}
+
+	return metrics
+}
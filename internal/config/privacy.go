@@ -0,0 +1,25 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-whois
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package config
+
+import (
+	"github.com/atc0005/check-whois/internal/domain"
+)
+
+// ExpectedPrivacyModes provides the (validated) registrant privacy modes
+// permitted for the domain.
+func (c Config) ExpectedPrivacyModes() []domain.PrivacyMode {
+	modes := make([]domain.PrivacyMode, 0, len(c.ExpectedPrivacy))
+	for _, value := range c.ExpectedPrivacy {
+		if mode, err := domain.ParsePrivacyMode(value); err == nil {
+			modes = append(modes, mode)
+		}
+	}
+
+	return modes
+}
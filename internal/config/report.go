@@ -0,0 +1,18 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-whois
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package config
+
+import (
+	"strings"
+)
+
+// VerboseReport indicates whether the plugin report should list every
+// contact block from the registration data.
+func (c Config) VerboseReport() bool {
+	return strings.EqualFold(c.ReportLevel, ReportLevelVerbose)
+}
@@ -0,0 +1,78 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-whois
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/atc0005/go-nagios"
+)
+
+// supportedStateLabels is the list of service state labels which may be
+// specified for configurable states (e.g., the state used when the plugin
+// timeout is reached).
+func supportedStateLabels() []string {
+	return []string{
+		nagios.StateOKLabel,
+		nagios.StateWARNINGLabel,
+		nagios.StateCRITICALLabel,
+		nagios.StateUNKNOWNLabel,
+	}
+}
+
+// validateStateLabel asserts that the given service state label is
+// supported. Labels are case-insensitive.
+func validateStateLabel(label string) error {
+	for _, supported := range supportedStateLabels() {
+		if strings.EqualFold(label, supported) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf(
+		"unsupported state %q; supported states are %s",
+		label,
+		strings.ToLower(strings.Join(supportedStateLabels(), ", ")),
+	)
+}
+
+// serviceState provides the service state for the given (validated) state
+// label.
+func serviceState(label string) nagios.ServiceState {
+	label = strings.ToUpper(label)
+
+	return nagios.ServiceState{
+		Label:    label,
+		ExitCode: nagios.StateLabelToExitCode(label),
+	}
+}
+
+// TimeoutServiceState provides the service state used when the plugin
+// timeout is reached.
+func (c Config) TimeoutServiceState() nagios.ServiceState {
+	return serviceState(c.TimeoutState)
+}
+
+// PrivacyMismatchServiceState provides the service state used when the
+// registrant privacy mode does not match any of the expected privacy modes.
+func (c Config) PrivacyMismatchServiceState() nagios.ServiceState {
+	return serviceState(c.PrivacyMismatchState)
+}
+
+// MissingLockServiceState provides the service state used when required
+// registry or registrar lock status codes are not set.
+func (c Config) MissingLockServiceState() nagios.ServiceState {
+	return serviceState(c.MissingLockState)
+}
+
+// MissingExpirationServiceState provides the service state used when the
+// domain expiration date is missing from the registration data.
+func (c Config) MissingExpirationServiceState() nagios.ServiceState {
+	return serviceState(c.MissingExpirationState)
+}
@@ -0,0 +1,49 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-whois
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/atc0005/check-whois/internal/domain"
+)
+
+// parseTemplateVar parses the key and value from the given template
+// variable entry (e.g., ticket=INC0012345).
+func parseTemplateVar(entry string) (string, string, error) {
+	key, value, found := strings.Cut(entry, "=")
+	key = strings.TrimSpace(key)
+	if !found || key == "" {
+		return "", "", fmt.Errorf(
+			"invalid template variable %q; expected key=value (e.g., ticket=INC0012345)",
+			entry,
+		)
+	}
+
+	return key, strings.TrimSpace(value), nil
+}
+
+// templateVars provides the (validated) user-specified template variables.
+func (c Config) templateVars() map[string]string {
+	vars := make(map[string]string, len(c.TemplateVars))
+
+	for _, entry := range c.TemplateVars {
+		if key, value, err := parseTemplateVar(entry); err == nil {
+			vars[key] = value
+		}
+	}
+
+	return vars
+}
+
+// Templates provides the (validated) summary and report templates used in
+// place of the default wording, or nil if neither template was specified.
+func (c Config) Templates() *domain.Templates {
+	return c.templates
+}
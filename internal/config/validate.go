@@ -9,54 +9,539 @@ package config
 
 import (
 	"fmt"
+	"net/url"
+	"os"
 	"strings"
+
+	"github.com/atc0005/check-whois/internal/domain"
+	"github.com/atc0005/check-whois/internal/inventory"
+	"github.com/atc0005/check-whois/internal/lookalike"
+	"github.com/atc0005/check-whois/internal/notify"
+	"github.com/atc0005/check-whois/internal/output"
 )
 
 // validate verifies all Config struct fields have been provided acceptable
 // values.
-func (c Config) validate() error {
+func (c Config) validate(appType AppType) error {
 
-	if c.Domain == "" {
+	// Domain names are provided via API requests to the API server.
+	if !appType.Server && c.Domain == "" && len(c.Domains) == 0 {
 		return fmt.Errorf(
 			"domain to query not provided",
 		)
 	}
 
-	if c.AgeWarning < 0 {
+	if err := c.validateLookup(); err != nil {
+		return err
+	}
+
+	if err := c.validateDateFormat(); err != nil {
+		return err
+	}
+
+	switch {
+	case appType.Plugin:
+		if err := c.validatePlugin(); err != nil {
+			return err
+		}
+
+		if err := c.validatePluginOutput(); err != nil {
+			return err
+		}
+
+	case appType.LookalikePlugin:
+		if err := c.validateLookalikePlugin(); err != nil {
+			return err
+		}
+
+	case appType.Calendar:
+		if err := c.validateCalendar(); err != nil {
+			return err
+		}
+
+	case appType.Server:
+		if err := c.validateServer(); err != nil {
+			return err
+		}
+
+	case appType.Inventory:
+		if err := c.validateInventory(); err != nil {
+			return err
+		}
+	}
+
+	requestedLoggingLevel := strings.ToLower(c.LoggingLevel)
+	if _, ok := loggingLevels[requestedLoggingLevel]; !ok {
+		return fmt.Errorf("invalid logging level %q", c.LoggingLevel)
+	}
+
+	// Optimist
+	return nil
+
+}
+
+// validateLookup verifies Config struct fields used to retrieve domain
+// registration data have been provided acceptable values.
+func (c Config) validateLookup() error {
+
+	switch strings.ToLower(c.LookupMethod) {
+	case LookupMethodWHOIS, LookupMethodRDAP:
+	case LookupMethodFile:
+		if c.LookupFile == "" {
+			return fmt.Errorf(
+				"registration data file not provided for %s lookup method",
+				LookupMethodFile,
+			)
+		}
+	default:
 		return fmt.Errorf(
-			"invalid cert expiration WARNING threshold number: %d",
-			c.AgeWarning,
+			"invalid lookup method %q; supported methods are %s, %s and %s",
+			c.LookupMethod,
+			LookupMethodWHOIS,
+			LookupMethodRDAP,
+			LookupMethodFile,
 		)
 	}
 
-	if c.AgeCritical < 0 {
+	if c.RDAPServer != "" {
+		u, err := url.Parse(c.RDAPServer)
+		if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+			return fmt.Errorf("invalid RDAP server URL %q", c.RDAPServer)
+		}
+	}
+
+	if c.Timeout <= 0 {
 		return fmt.Errorf(
-			"invalid cert expiration CRITICAL threshold number: %d",
-			c.AgeCritical,
+			"invalid timeout %v; a positive duration is required",
+			c.Timeout,
 		)
 	}
 
-	if c.AgeCritical > c.AgeWarning {
+	if err := validateStateLabel(c.TimeoutState); err != nil {
+		return fmt.Errorf("invalid timeout state: %w", err)
+	}
+
+	if c.Retries < 0 {
 		return fmt.Errorf(
-			"critical threshold set higher than warning threshold",
+			"invalid retries value %d; a value of 0 or greater is required",
+			c.Retries,
 		)
 	}
 
-	if c.AgeCritical == c.AgeWarning {
+	if c.RetryDelay < 0 {
 		return fmt.Errorf(
-			"critical threshold (%d) set equal to warning threshold (%d); "+
-				"critical threshold should be lower than warning threshold",
-			c.AgeCritical,
-			c.AgeWarning,
+			"invalid retry delay %v; a non-negative duration is required",
+			c.RetryDelay,
 		)
 	}
 
-	requestedLoggingLevel := strings.ToLower(c.LoggingLevel)
-	if _, ok := loggingLevels[requestedLoggingLevel]; !ok {
-		return fmt.Errorf("invalid logging level %q", c.LoggingLevel)
+	if c.CacheDir != "" && c.CacheTTL <= 0 {
+		return fmt.Errorf(
+			"invalid cache TTL %v; a positive duration is required",
+			c.CacheTTL,
+		)
 	}
 
-	// Optimist
 	return nil
 
 }
+
+// validateDateFormat verifies Config struct fields used to display dates
+// have been provided acceptable values.
+func (c Config) validateDateFormat() error {
+
+	if _, err := domain.ParseDateLayout(c.DateFormat); err != nil {
+		return err
+	}
+
+	if _, err := domain.ParseDisplayLocation(c.DisplayTimeZone); err != nil {
+		return err
+	}
+
+	return nil
+
+}
+
+// validatePlugin verifies Config struct fields specific to the domain
+// expiration plugin have been provided acceptable values.
+func (c Config) validatePlugin() error {
+
+	if !c.AgeWarning.IsSet() {
+		return fmt.Errorf(
+			"domain expiration WARNING threshold not provided",
+		)
+	}
+
+	if !c.AgeCritical.IsSet() {
+		return fmt.Errorf(
+			"domain expiration CRITICAL threshold not provided",
+		)
+	}
+
+	if err := validateThresholdOrder(c.AgeWarning, c.AgeCritical); err != nil {
+		return fmt.Errorf("invalid domain expiration thresholds: %w", err)
+	}
+
+	if err := c.validateEvaluation(); err != nil {
+		return err
+	}
+
+	if err := validateStateLabel(c.MissingExpirationState); err != nil {
+		return fmt.Errorf("invalid missing expiration date state: %w", err)
+	}
+
+	switch strings.ToLower(c.ReportLevel) {
+	case ReportLevelStandard, ReportLevelVerbose:
+	default:
+		return fmt.Errorf(
+			"invalid report level %q; supported levels are %s and %s",
+			c.ReportLevel,
+			ReportLevelStandard,
+			ReportLevelVerbose,
+		)
+	}
+
+	for _, entry := range c.TemplateVars {
+		if _, _, err := parseTemplateVar(entry); err != nil {
+			return err
+		}
+	}
+
+	for _, metric := range c.Metrics {
+		if _, err := domain.ParseMetric(metric); err != nil {
+			return fmt.Errorf("invalid performance data metric: %w", err)
+		}
+	}
+
+	return nil
+
+}
+
+// validateEvaluation verifies the optional Config struct fields shared by
+// the applications which report the state of each domain in the same way as
+// the domain expiration plugin.
+func (c Config) validateEvaluation() error {
+
+	// The updated and created date thresholds are optional and may be
+	// specified independently of each other.
+	if c.UpdatedWarning.IsSet() && c.UpdatedCritical.IsSet() {
+		if err := validateThresholdOrder(c.UpdatedWarning, c.UpdatedCritical); err != nil {
+			return fmt.Errorf("invalid domain updated date thresholds: %w", err)
+		}
+	}
+
+	if c.CreatedWarning.IsSet() && c.CreatedCritical.IsSet() {
+		if err := validateThresholdOrder(c.CreatedWarning, c.CreatedCritical); err != nil {
+			return fmt.Errorf("invalid domain created date thresholds: %w", err)
+		}
+	}
+
+	for _, mode := range c.ExpectedPrivacy {
+		if _, err := domain.ParsePrivacyMode(mode); err != nil {
+			return fmt.Errorf("invalid expected privacy mode: %w", err)
+		}
+	}
+
+	if err := validateStateLabel(c.PrivacyMismatchState); err != nil {
+		return fmt.Errorf("invalid privacy mismatch state: %w", err)
+	}
+
+	for _, entry := range c.GracePeriods {
+		if _, _, err := parseGracePeriods(entry); err != nil {
+			return err
+		}
+	}
+
+	if err := validateStateLabel(c.MissingLockState); err != nil {
+		return fmt.Errorf("invalid missing lock state: %w", err)
+	}
+
+	return nil
+
+}
+
+// validatePluginOutput verifies Config struct fields specific to the output
+// of the domain expiration plugin have been provided acceptable values.
+func (c Config) validatePluginOutput() error {
+
+	if c.CommandFile != "" && !strings.EqualFold(c.OutputFormat, string(output.FormatPassive)) {
+		return fmt.Errorf(
+			"command file specified; the command file is only used by the %s output format",
+			output.FormatPassive,
+		)
+	}
+
+	if strings.EqualFold(c.OutputFormat, OutputFormatNagios) {
+		if len(c.DomainList()) > 1 {
+			return fmt.Errorf(
+				"multiple domains provided; the %s output format supports a single domain",
+				OutputFormatNagios,
+			)
+		}
+
+		// Nagios notifies of state changes for single domain checks.
+		if c.WebhookURL != "" {
+			return fmt.Errorf(
+				"webhook URL specified; webhook notifications require an output format other than %s",
+				OutputFormatNagios,
+			)
+		}
+
+		return nil
+	}
+
+	if _, err := output.ParseFormat(c.OutputFormat); err != nil {
+		return fmt.Errorf(
+			"invalid output format: %w (or %s)",
+			err,
+			OutputFormatNagios,
+		)
+	}
+
+	if _, err := output.ParseNames(c.HostName, c.ServiceName); err != nil {
+		return fmt.Errorf("invalid name template: %w", err)
+	}
+
+	// The Nagios command file is created by Nagios when external commands
+	// are enabled.
+	if c.CommandFile != "" {
+		if _, err := os.Stat(c.CommandFile); err != nil {
+			return fmt.Errorf("invalid command file: %w", err)
+		}
+	}
+
+	if c.Concurrency < 1 {
+		return fmt.Errorf(
+			"invalid concurrency value %d; a value of 1 or greater is required",
+			c.Concurrency,
+		)
+	}
+
+	if err := c.validateNotifyStateFile(); err != nil {
+		return err
+	}
+
+	return c.validateNotify()
+
+}
+
+// validateLookalikePlugin verifies Config struct fields specific to the
+// lookalike domain plugin have been provided acceptable values.
+func (c Config) validateLookalikePlugin() error {
+
+	if !c.CreatedWarning.IsSet() || !c.CreatedCritical.IsSet() {
+		return fmt.Errorf(
+			"lookalike domain created date thresholds not provided",
+		)
+	}
+
+	if err := validateThresholdOrder(c.CreatedWarning, c.CreatedCritical); err != nil {
+		return fmt.Errorf("invalid lookalike domain created date thresholds: %w", err)
+	}
+
+	for _, permutation := range c.Permutations {
+		if _, err := lookalike.ParseKind(permutation); err != nil {
+			return fmt.Errorf("invalid lookalike permutation kind: %w", err)
+		}
+	}
+
+	for _, tld := range c.TLDs {
+		if _, err := domain.ASCIIName(tld); err != nil {
+			return fmt.Errorf("invalid TLD %q for tld-swap permutations: %w", tld, err)
+		}
+	}
+
+	if c.Concurrency < 1 {
+		return fmt.Errorf(
+			"invalid concurrency value %d; a value of 1 or greater is required",
+			c.Concurrency,
+		)
+	}
+
+	return nil
+
+}
+
+// validateCalendar verifies Config struct fields specific to the calendar
+// export application have been provided acceptable values.
+func (c Config) validateCalendar() error {
+
+	if !c.AgeWarning.IsSet() || !c.AgeCritical.IsSet() {
+		return fmt.Errorf(
+			"domain expiration reminder thresholds not provided",
+		)
+	}
+
+	// Reminders are scheduled relative to the expiration date, which
+	// requires a simple threshold.
+	if c.AgeWarning.IsRange() || c.AgeCritical.IsRange() {
+		return fmt.Errorf(
+			"domain expiration reminder thresholds must be a number of days or duration",
+		)
+	}
+
+	if err := validateThresholdOrder(c.AgeWarning, c.AgeCritical); err != nil {
+		return fmt.Errorf("invalid domain expiration thresholds: %w", err)
+	}
+
+	if c.Concurrency < 1 {
+		return fmt.Errorf(
+			"invalid concurrency value %d; a value of 1 or greater is required",
+			c.Concurrency,
+		)
+	}
+
+	return nil
+
+}
+
+// validateInventory verifies Config struct fields specific to the inventory
+// report application have been provided acceptable values.
+func (c Config) validateInventory() error {
+
+	if !c.AgeWarning.IsSet() || !c.AgeCritical.IsSet() {
+		return fmt.Errorf(
+			"domain expiration thresholds not provided",
+		)
+	}
+
+	if err := validateThresholdOrder(c.AgeWarning, c.AgeCritical); err != nil {
+		return fmt.Errorf("invalid domain expiration thresholds: %w", err)
+	}
+
+	// Domains are evaluated in the same way as the plugin.
+	if err := c.validateEvaluation(); err != nil {
+		return err
+	}
+
+	if c.Concurrency < 1 {
+		return fmt.Errorf(
+			"invalid concurrency value %d; a value of 1 or greater is required",
+			c.Concurrency,
+		)
+	}
+
+	if _, err := inventory.ParseFormat(c.InventoryFormat); err != nil {
+		return fmt.Errorf("invalid inventory format: %w", err)
+	}
+
+	if _, err := inventory.ParseSortKey(c.InventorySort); err != nil {
+		return fmt.Errorf("invalid inventory sort column: %w", err)
+	}
+
+	if err := c.validateNotifyStateFile(); err != nil {
+		return err
+	}
+
+	return c.validateNotify()
+
+}
+
+// validateServer verifies Config struct fields specific to the API server
+// have been provided acceptable values.
+func (c Config) validateServer() error {
+
+	// Domains are evaluated in the same way as the plugin.
+	if err := c.validatePlugin(); err != nil {
+		return err
+	}
+
+	if c.ListenAddress == "" {
+		return fmt.Errorf(
+			"listen address not provided",
+		)
+	}
+
+	if c.Concurrency < 1 {
+		return fmt.Errorf(
+			"invalid concurrency value %d; a value of 1 or greater is required",
+			c.Concurrency,
+		)
+	}
+
+	if c.ResultCacheTTL < 0 {
+		return fmt.Errorf(
+			"invalid result cache TTL %v; a value of 0 or greater is required",
+			c.ResultCacheTTL,
+		)
+	}
+
+	return c.validateNotify()
+
+}
+
+// validateNotifyStateFile asserts that a notification state file is
+// provided when webhook notifications are sent by applications which
+// evaluate domains once per execution; state transitions can only be
+// detected by comparing against the states recorded by an earlier
+// execution.
+func (c Config) validateNotifyStateFile() error {
+	if c.WebhookURL != "" && c.NotifyStateFile == "" {
+		return fmt.Errorf(
+			"notification state file not provided; required when a webhook URL is specified",
+		)
+	}
+
+	return nil
+}
+
+// validateNotify verifies Config struct fields specific to webhook
+// notifications have been provided acceptable values.
+func (c Config) validateNotify() error {
+
+	if c.WebhookURL == "" {
+		return nil
+	}
+
+	u, err := url.Parse(c.WebhookURL)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return fmt.Errorf("invalid webhook URL %q", c.WebhookURL)
+	}
+
+	if _, err := notify.ParseFormat(c.WebhookFormat); err != nil {
+		return fmt.Errorf("invalid webhook format: %w", err)
+	}
+
+	if c.WebhookRetries < 0 {
+		return fmt.Errorf(
+			"invalid webhook retries value %d; a value of 0 or greater is required",
+			c.WebhookRetries,
+		)
+	}
+
+	if c.WebhookRetryDelay < 0 {
+		return fmt.Errorf(
+			"invalid webhook retry delay %v; a non-negative duration is required",
+			c.WebhookRetryDelay,
+		)
+	}
+
+	return nil
+
+}
+
+// validateThresholdOrder asserts that the given CRITICAL threshold is lower
+// than the WARNING threshold. Relative threshold ordering can only be
+// asserted when neither threshold is specified using Nagios range syntax.
+func validateThresholdOrder(warning domain.Threshold, critical domain.Threshold) error {
+	if warning.IsRange() || critical.IsRange() {
+		return nil
+	}
+
+	if critical.Duration() > warning.Duration() {
+		return fmt.Errorf(
+			"critical threshold set higher than warning threshold",
+		)
+	}
+
+	if critical.Duration() == warning.Duration() {
+		return fmt.Errorf(
+			"critical threshold (%s) set equal to warning threshold (%s); "+
+				"critical threshold should be lower than warning threshold",
+			critical,
+			warning,
+		)
+	}
+
+	return nil
+}
@@ -0,0 +1,193 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-whois
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package config
+
+import (
+	"testing"
+
+	"github.com/atc0005/check-whois/internal/domain"
+)
+
+// TestValidateThresholdOrder asserts that CRITICAL thresholds must be lower
+// than WARNING thresholds unless either is specified as a Nagios range.
+func TestValidateThresholdOrder(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		warning  string
+		critical string
+		wantErr  bool
+	}{
+		"days":                    {warning: "30", critical: "15"},
+		"durations":               {warning: "2w", critical: "72h"},
+		"days and duration":       {warning: "30", critical: "1w"},
+		"critical higher":         {warning: "15", critical: "30", wantErr: true},
+		"critical equal":          {warning: "30", critical: "30", wantErr: true},
+		"critical equal duration": {warning: "7", critical: "1w", wantErr: true},
+		"warning range":           {warning: "@0:15", critical: "30"},
+		"critical range":          {warning: "15", critical: "@0:30"},
+		"both ranges":             {warning: "@0:15", critical: "@0:30"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			warning, err := domain.ParseThreshold(tt.warning)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			critical, err := domain.ParseThreshold(tt.critical)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = validateThresholdOrder(warning, critical)
+			if tt.wantErr && err == nil {
+				t.Error("want error, got nil")
+			}
+
+			if !tt.wantErr && err != nil {
+				t.Errorf("want no error, got %v", err)
+			}
+		})
+	}
+}
+
+// TestValidatePluginDateThresholds asserts that the optional updated and
+// created date thresholds of the domain expiration plugin may be specified
+// independently and are ordered when both are specified.
+func TestValidatePluginDateThresholds(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		updatedWarning  string
+		updatedCritical string
+		createdWarning  string
+		createdCritical string
+		wantErr         bool
+	}{
+		"not set": {},
+		"updated": {
+			updatedWarning:  "7",
+			updatedCritical: "1",
+		},
+		"updated warning only": {
+			updatedWarning: "7",
+		},
+		"updated order": {
+			updatedWarning:  "1",
+			updatedCritical: "7",
+			wantErr:         true,
+		},
+		"created": {
+			createdWarning:  "30",
+			createdCritical: "7",
+		},
+		"created critical only": {
+			createdCritical: "7",
+		},
+		"created order": {
+			createdWarning:  "24h",
+			createdCritical: "1",
+			wantErr:         true,
+		},
+		"created ranges": {
+			createdWarning:  "@0:7",
+			createdCritical: "@0:30",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			c := Config{
+				MissingExpirationState: defaultMissingExpirationState,
+				PrivacyMismatchState:   defaultPrivacyMismatchState,
+				MissingLockState:       defaultMissingLockState,
+				ReportLevel:            defaultReportLevel,
+			}
+
+			thresholds := []struct {
+				value     string
+				threshold *domain.Threshold
+			}{
+				{value: defaultDomainExpireAgeWarning, threshold: &c.AgeWarning},
+				{value: defaultDomainExpireAgeCritical, threshold: &c.AgeCritical},
+				{value: tt.updatedWarning, threshold: &c.UpdatedWarning},
+				{value: tt.updatedCritical, threshold: &c.UpdatedCritical},
+				{value: tt.createdWarning, threshold: &c.CreatedWarning},
+				{value: tt.createdCritical, threshold: &c.CreatedCritical},
+			}
+
+			for _, entry := range thresholds {
+				if entry.value == "" {
+					continue
+				}
+
+				if err := entry.threshold.Set(entry.value); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			err := c.validatePlugin()
+			if tt.wantErr && err == nil {
+				t.Error("want error, got nil")
+			}
+
+			if !tt.wantErr && err != nil {
+				t.Errorf("want no error, got %v", err)
+			}
+		})
+	}
+}
+
+// TestValidateCalendarThresholds asserts that calendar reminder thresholds
+// must be ordered and may not be specified as Nagios ranges.
+func TestValidateCalendarThresholds(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		warning  string
+		critical string
+		wantErr  bool
+	}{
+		"days":            {warning: "30", critical: "15"},
+		"durations":       {warning: "2w", critical: "36h"},
+		"critical higher": {warning: "15", critical: "30", wantErr: true},
+		"warning range":   {warning: "@0:30", critical: "15", wantErr: true},
+		"critical range":  {warning: "30", critical: "@0:15", wantErr: true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			c := Config{Concurrency: defaultConcurrency}
+
+			if err := c.AgeWarning.Set(tt.warning); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := c.AgeCritical.Set(tt.critical); err != nil {
+				t.Fatal(err)
+			}
+
+			err := c.validateCalendar()
+			if tt.wantErr && err == nil {
+				t.Error("want error, got nil")
+			}
+
+			if !tt.wantErr && err != nil {
+				t.Errorf("want no error, got %v", err)
+			}
+		})
+	}
+}
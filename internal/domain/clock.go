@@ -0,0 +1,46 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-whois
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package domain
+
+import "time"
+
+// Clock provides the current time used when evaluating domain metadata.
+type Clock interface {
+
+	// Now provides the current time.
+	Now() time.Time
+}
+
+// SystemClock is a Clock which provides the current system time.
+type SystemClock struct{}
+
+// Now provides the current system time.
+func (SystemClock) Now() time.Time {
+	return time.Now()
+}
+
+// FixedClock is a Clock which always provides the same time. This is used to
+// evaluate domain metadata as of a specific date (e.g., to determine what
+// state a domain will be in on a future date) and for testing.
+type FixedClock time.Time
+
+// Now provides the fixed time.
+func (fc FixedClock) Now() time.Time {
+	return time.Time(fc)
+}
+
+// Now provides the current time using the domain's Clock, falling back to
+// the system time if a Clock is not set. This is the time the domain
+// metadata is evaluated against.
+func (m Metadata) Now() time.Time {
+	if m.Clock == nil {
+		return time.Now()
+	}
+
+	return m.Clock.Now()
+}
@@ -0,0 +1,156 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-whois
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/atc0005/go-nagios"
+)
+
+// TestFixedClockExpirationBoundary asserts that domain metadata is evaluated
+// against the Clock at (and either side of) the expiration and threshold
+// boundaries.
+func TestFixedClockExpirationBoundary(t *testing.T) {
+	t.Parallel()
+
+	expiration := time.Date(2026, 12, 31, 12, 0, 0, 0, time.UTC)
+
+	warning, err := ParseThreshold("30")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	critical, err := ParseThreshold("15")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		now         time.Time
+		wantExpired bool
+		wantState   string
+		wantDays    int
+		wantText    string
+	}{
+		"before warning threshold": {
+			now:       expiration.Add(-30*day - time.Second),
+			wantState: nagios.StateOKLabel,
+			wantDays:  30,
+			wantText:  "30d 0h remaining",
+		},
+		"at warning threshold": {
+			now:       expiration.Add(-30 * day),
+			wantState: nagios.StateOKLabel,
+			wantDays:  30,
+			wantText:  "30d 0h remaining",
+		},
+		"just inside warning threshold": {
+			now:       expiration.Add(-30*day + 15*time.Minute),
+			wantState: nagios.StateWARNINGLabel,
+			wantDays:  29,
+			wantText:  "29d 23h remaining",
+		},
+		"just inside critical threshold": {
+			now:       expiration.Add(-15*day + 15*time.Minute),
+			wantState: nagios.StateCRITICALLabel,
+			wantDays:  14,
+			wantText:  "14d 23h remaining",
+		},
+		"at expiration": {
+			now:       expiration,
+			wantState: nagios.StateCRITICALLabel,
+			wantDays:  0,
+			wantText:  " 0h remaining",
+		},
+		"just after expiration": {
+			now:         expiration.Add(time.Second),
+			wantExpired: true,
+			wantState:   nagios.StateCRITICALLabel,
+			wantDays:    0,
+			wantText:    " 0h ago",
+		},
+		"long after expiration": {
+			now:         expiration.Add(2*day + 3*time.Hour),
+			wantExpired: true,
+			wantState:   nagios.StateCRITICALLabel,
+			wantDays:    -2,
+			wantText:    "2d 3h ago",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			m := Metadata{
+				Name:                 "example.com",
+				ExpirationDate:       expiration,
+				UpdatedDate:          expiration.AddDate(-1, 0, 0),
+				CreatedDate:          expiration.AddDate(-10, 0, 0),
+				AgeWarningThreshold:  warning,
+				AgeCriticalThreshold: critical,
+				Clock:                FixedClock(tt.now),
+			}
+
+			if got := m.IsExpired(); got != tt.wantExpired {
+				t.Errorf("want expired %t, got %t", tt.wantExpired, got)
+			}
+
+			if got := m.ServiceState().Label; got != tt.wantState {
+				t.Errorf("want state %s, got %s", tt.wantState, got)
+			}
+
+			days, err := UntilExpiration(&m)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if days != tt.wantDays {
+				t.Errorf("want %d days until expiration, got %d", tt.wantDays, days)
+			}
+
+			if got := FormattedExpiration(m.ExpirationDate, m.Now()); got != tt.wantText {
+				t.Errorf("\nwant %q\ngot %q", tt.wantText, got)
+			}
+		})
+	}
+}
+
+// TestFixedClockSinceUpdateAndCreation asserts that the days since the
+// domain was updated and created are calculated using the Clock.
+func TestFixedClockSinceUpdateAndCreation(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	m := Metadata{
+		UpdatedDate: now.Add(-7*day - time.Hour),
+		CreatedDate: now.Add(-365 * day),
+		Clock:       FixedClock(now),
+	}
+
+	updated, err := SinceUpdate(&m)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	created, err := SinceCreation(&m)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if updated != 7 || created != 365 {
+		t.Errorf("want 7 and 365 days, got %d and %d", updated, created)
+	}
+
+	if got := m.UpdatedValue(); got != "7.04" {
+		t.Errorf("want updated value 7.04, got %s", got)
+	}
+}
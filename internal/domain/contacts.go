@@ -0,0 +1,184 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-whois
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package domain
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/atc0005/go-nagios"
+	whoisparser "github.com/likexian/whois-parser"
+)
+
+// defaultRedactedPlaceholderValue is used in place of WHOIS record values
+// which have been redacted by the registry or registrar (e.g., for GDPR
+// compliance).
+const defaultRedactedPlaceholderValue string = "redacted"
+
+// redactionMarkers is the list of (lowercase) values used by registries and
+// registrars in place of redacted contact details. A value containing any of
+// these markers is treated as redacted.
+var redactionMarkers = []string{
+	"redacted",
+	"data protected",
+	"not disclosed",
+	"non-public data",
+	"gdpr masked",
+	"statutory masking enabled",
+	"hidden upon user request",
+	"privacy protected",
+	"not available from registry",
+	"please query the rdds service",
+	"visit whois.auda.org.au",
+	"select request email form",
+}
+
+// IsRedacted indicates whether the given WHOIS record value is a redaction
+// marker (e.g., "REDACTED FOR PRIVACY" or "Data Protected") rather than
+// actual contact details.
+func IsRedacted(value string) bool {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if value == "" {
+		return false
+	}
+
+	for _, marker := range redactionMarkers {
+		if strings.Contains(value, marker) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// contactField is a labeled value from a WHOIS record contact block.
+type contactField struct {
+	label string
+	value string
+}
+
+// contactFields provides the non-empty fields of the given contact block in
+// display order.
+func contactFields(contact *whoisparser.Contact) []contactField {
+	if contact == nil {
+		return nil
+	}
+
+	withExt := func(number string, ext string) string {
+		if number == "" || ext == "" {
+			return number
+		}
+
+		return number + " ext. " + ext
+	}
+
+	fields := []contactField{
+		{label: "Name", value: contact.Name},
+		{label: "Organization", value: contact.Organization},
+		{label: "Street", value: contact.Street},
+		{label: "City", value: contact.City},
+		{label: "State/Province", value: contact.Province},
+		{label: "Postal Code", value: contact.PostalCode},
+		{label: "Country", value: contact.Country},
+		{label: "Phone", value: withExt(contact.Phone, contact.PhoneExt)},
+		{label: "Fax", value: withExt(contact.Fax, contact.FaxExt)},
+		{label: "Email", value: contact.Email},
+		{label: "URL", value: contact.ReferralURL},
+	}
+
+	specified := make([]contactField, 0, len(fields))
+	for _, field := range fields {
+		if strings.TrimSpace(field.value) != "" {
+			specified = append(specified, field)
+		}
+	}
+
+	return specified
+}
+
+// writeContact writes the details of the given contact block to the given
+// report using the given heading. Redacted values are labeled as such and a
+// contact block with only redacted values is summarized as redacted.
+func writeContact(report *strings.Builder, heading string, contact *whoisparser.Contact) {
+	fields := contactFields(contact)
+
+	redacted := 0
+	for _, field := range fields {
+		if IsRedacted(field.value) {
+			redacted++
+		}
+	}
+
+	switch {
+	case len(fields) == 0:
+		_, _ = fmt.Fprintf(
+			report,
+			"* %s: %s%s",
+			heading,
+			defaultWhoISPlaceholderValue,
+			nagios.CheckOutputEOL,
+		)
+
+		return
+
+	case redacted == len(fields):
+		_, _ = fmt.Fprintf(
+			report,
+			"* %s: %s%s",
+			heading,
+			defaultRedactedPlaceholderValue,
+			nagios.CheckOutputEOL,
+		)
+
+		return
+	}
+
+	_, _ = fmt.Fprintf(report, "* %s:%s", heading, nagios.CheckOutputEOL)
+
+	for _, field := range fields {
+		value := field.value
+		if IsRedacted(value) {
+			value = defaultRedactedPlaceholderValue
+		}
+
+		_, _ = fmt.Fprintf(
+			report,
+			"  * %s: %s%s",
+			field.label,
+			value,
+			nagios.CheckOutputEOL,
+		)
+	}
+}
+
+// VerboseReport provides the same overview of domain details as Report,
+// followed by every contact block (registrar, registrant, administrative,
+// technical and billing) available from the WHOIS record. Redacted contact
+// details are labeled as redacted instead of displaying the redaction
+// marker.
+func (m Metadata) VerboseReport() string {
+	var report strings.Builder
+
+	report.WriteString(m.Report())
+
+	_, _ = fmt.Fprintf(
+		&report,
+		"%sContacts:%s%s",
+		nagios.CheckOutputEOL,
+		nagios.CheckOutputEOL,
+		nagios.CheckOutputEOL,
+	)
+
+	writeContact(&report, "Registrar", m.WhoisInfo.Registrar)
+	writeContact(&report, "Registrant", m.WhoisInfo.Registrant)
+	writeContact(&report, "Administrative", m.WhoisInfo.Administrative)
+	writeContact(&report, "Technical", m.WhoisInfo.Technical)
+	writeContact(&report, "Billing", m.WhoisInfo.Billing)
+
+	return report.String()
+}
@@ -0,0 +1,113 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-whois
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package domain
+
+import (
+	"strings"
+	"testing"
+
+	whoisparser "github.com/likexian/whois-parser"
+)
+
+// TestIsRedacted asserts that redaction markers used by registries and
+// registrars are recognized without treating actual contact details as
+// redacted.
+func TestIsRedacted(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		value string
+		want  bool
+	}{
+		"empty":                {value: "", want: false},
+		"contact name":         {value: "Domain Administrator", want: false},
+		"privacy proxy":        {value: "Privacy service provided by Withheld for Privacy ehf", want: false},
+		"redacted for privacy": {value: "REDACTED FOR PRIVACY", want: true},
+		"data protected":       {value: "Data Protected", want: true},
+		"not disclosed":        {value: "Not Disclosed", want: true},
+		"gdpr masked":          {value: "GDPR Masked", want: true},
+		"rdds referral": {
+			value: "Please query the RDDS service of the Registrar of Record identified in this output for information on how to contact the Registrant, Admin, or Tech contact of the queried domain name.",
+			want:  true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := IsRedacted(tt.value); got != tt.want {
+				t.Errorf("want %t for %q, got %t", tt.want, tt.value, got)
+			}
+		})
+	}
+}
+
+// TestVerboseReport asserts that the verbose report lists each contact
+// block, labels redacted values and summarizes fully redacted or missing
+// contact blocks.
+func TestVerboseReport(t *testing.T) {
+	t.Parallel()
+
+	warning, critical := testThresholds(t)
+
+	info := whoisparser.WhoisInfo{
+		Domain: &whoisparser.Domain{
+			Domain:         "example.com",
+			ExpirationDate: "2027-08-12T04:00:00Z",
+		},
+		Registrar: &whoisparser.Contact{
+			Name:  "Example Registrar, LLC",
+			Phone: "+1.5555550100",
+		},
+		Registrant: &whoisparser.Contact{
+			Name:         "REDACTED FOR PRIVACY",
+			Organization: "Example Company, Inc.",
+			Phone:        "+1.5555550101",
+			PhoneExt:     "42",
+		},
+		Administrative: &whoisparser.Contact{
+			Name:  "REDACTED FOR PRIVACY",
+			Email: "Data Protected",
+		},
+	}
+
+	m, err := NewDomain(info, warning, critical)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	report := m.VerboseReport()
+
+	if !strings.HasPrefix(report, m.Report()) {
+		t.Errorf("want verbose report prefixed by standard report\ngot:\n%s", report)
+	}
+
+	want := []string{
+		"* Registrar:",
+		"  * Name: Example Registrar, LLC",
+		"* Registrant:",
+		"  * Name: redacted",
+		"  * Organization: Example Company, Inc.",
+		"  * Phone: +1.5555550101 ext. 42",
+		"* Administrative: redacted",
+		"* Technical: unspecified",
+		"* Billing: unspecified",
+	}
+
+	for _, line := range want {
+		if !strings.Contains(report, line) {
+			t.Errorf("\nwant report containing %q\ngot:\n%s", line, report)
+		}
+	}
+
+	contacts := strings.TrimPrefix(report, m.Report())
+	if strings.Contains(contacts, "REDACTED FOR PRIVACY") {
+		t.Errorf("want redaction markers replaced in contacts\ngot:\n%s", contacts)
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-whois
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Named date layout presets.
+const (
+	DateLayoutDefault  string = "default"
+	DateLayoutRFC3339  string = "rfc3339"
+	DateLayoutRFC1123  string = "rfc1123"
+	DateLayoutISODate  string = "iso-date"
+	DateLayoutDateTime string = "datetime"
+)
+
+// dateLayoutPresets maps the named date layout presets to Go time layouts.
+var dateLayoutPresets = map[string]string{
+	DateLayoutDefault:  DomainDateLayout,
+	DateLayoutRFC3339:  time.RFC3339,
+	DateLayoutRFC1123:  time.RFC1123Z,
+	DateLayoutISODate:  "2006-01-02",
+	DateLayoutDateTime: "2006-01-02 15:04 MST",
+}
+
+// DisplayLocationLocal is the display time zone value indicating the local
+// time zone of the system.
+const DisplayLocationLocal string = "Local"
+
+// DateFormat is the layout and time zone used to display dates. The zero
+// value displays dates using DomainDateLayout in the time zone provided by
+// the registration data (or used to calculate the date).
+type DateFormat struct {
+
+	// Layout is the Go time layout used to display dates. DomainDateLayout
+	// is used if not specified.
+	Layout string
+
+	// Location is the time zone used to display dates. Dates are displayed
+	// as-is if not specified.
+	Location *time.Location
+}
+
+// DateLayoutPresets provides the list of named date layout presets.
+func DateLayoutPresets() []string {
+	return []string{
+		DateLayoutDefault,
+		DateLayoutRFC3339,
+		DateLayoutRFC1123,
+		DateLayoutISODate,
+		DateLayoutDateTime,
+	}
+}
+
+// ParseDateLayout converts the given named preset (e.g., rfc3339) or Go
+// time layout (e.g., "02 Jan 2006 15:04 MST") into a Go time layout.
+func ParseDateLayout(value string) (string, error) {
+	if layout, ok := dateLayoutPresets[strings.ToLower(strings.TrimSpace(value))]; ok {
+		return layout, nil
+	}
+
+	// A layout without any reference time elements would display every
+	// date as the same text; this is most likely a misspelled preset.
+	sample := time.Date(2001, time.February, 3, 4, 5, 6, 0, time.UTC)
+	if strings.TrimSpace(value) == "" || sample.Format(value) == value {
+		return "", fmt.Errorf(
+			"invalid date format %q; expected a Go time layout (e.g., 2006-01-02 15:04) or one of %s",
+			value,
+			strings.Join(DateLayoutPresets(), ", "),
+		)
+	}
+
+	return value, nil
+}
+
+// ParseDisplayLocation converts the given IANA time zone name (e.g.,
+// America/Chicago), UTC or Local into a time zone used to display dates. A
+// nil time zone is returned for an empty value; dates are displayed as-is.
+func ParseDisplayLocation(value string) (*time.Location, error) {
+	value = strings.TrimSpace(value)
+
+	switch {
+	case value == "":
+		return nil, nil
+	case strings.EqualFold(value, DisplayLocationLocal):
+		return time.Local, nil
+	case strings.EqualFold(value, "UTC"):
+		return time.UTC, nil
+	}
+
+	location, err := time.LoadLocation(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid display time zone %q: %w", value, err)
+	}
+
+	return location, nil
+}
+
+// In provides the given date in the display time zone, or as-is if a
+// display time zone is not specified.
+func (f DateFormat) In(date time.Time) time.Time {
+	if f.Location == nil {
+		return date
+	}
+
+	return date.In(f.Location)
+}
+
+// Format provides the given date formatted for display or the
+// fallback/placeholder value if the date was not specified.
+func (f DateFormat) Format(date time.Time) string {
+	if date.IsZero() {
+		return defaultWhoISPlaceholderValue
+	}
+
+	layout := f.Layout
+	if layout == "" {
+		layout = DomainDateLayout
+	}
+
+	return f.In(date).Format(layout)
+}
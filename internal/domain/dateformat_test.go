@@ -0,0 +1,92 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-whois
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+// TestDateFormat asserts that named presets and Go time layouts are
+// accepted and that dates are displayed in the display time zone.
+func TestDateFormat(t *testing.T) {
+	t.Parallel()
+
+	// A registry providing dates in a fixed +09:00 offset.
+	date := time.Date(2027, 8, 12, 4, 0, 0, 0, time.FixedZone("JST", 9*60*60))
+
+	tests := map[string]struct {
+		format   string
+		timezone string
+		want     string
+		wantErr  bool
+	}{
+		"default": {
+			format: DateLayoutDefault,
+			want:   "2027-08-12 04:00:00 +0900 JST",
+		},
+		"preset in display time zone": {
+			format:   "RFC3339",
+			timezone: "UTC",
+			want:     "2027-08-11T19:00:00Z",
+		},
+		"iso date in named time zone": {
+			format:   DateLayoutISODate,
+			timezone: "America/Chicago",
+			want:     "2027-08-11",
+		},
+		"go layout": {
+			format:   "02 Jan 2006 15:04 MST",
+			timezone: "America/Chicago",
+			want:     "11 Aug 2027 14:00 CDT",
+		},
+		"layout without reference time elements": {
+			format:  "iso",
+			wantErr: true,
+		},
+		"empty layout": {
+			format:  " ",
+			wantErr: true,
+		},
+		"unknown time zone": {
+			format:   DateLayoutDefault,
+			timezone: "Mars/Olympus_Mons",
+			wantErr:  true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			layout, layoutErr := ParseDateLayout(tt.format)
+			location, locationErr := ParseDisplayLocation(tt.timezone)
+
+			if tt.wantErr {
+				if layoutErr == nil && locationErr == nil {
+					t.Fatal("want error, got nil")
+				}
+
+				return
+			}
+
+			if layoutErr != nil || locationErr != nil {
+				t.Fatalf("unexpected errors: %v, %v", layoutErr, locationErr)
+			}
+
+			format := DateFormat{Layout: layout, Location: location}
+			if got := format.Format(date); got != tt.want {
+				t.Errorf("want %q, got %q", tt.want, got)
+			}
+
+			if got := format.Format(time.Time{}); got != defaultWhoISPlaceholderValue {
+				t.Errorf("want placeholder for unspecified date, got %q", got)
+			}
+		})
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"slices"
 	"strings"
 	"time"
 
@@ -32,9 +33,23 @@ var ErrDomainExpired = errors.New("domain has expired")
 // ErrDomainExpiring is returned whenever a specified domain is expiring.
 var ErrDomainExpiring = errors.New("domain is expiring")
 
+// ErrDomainRecentlyUpdated is returned whenever the WHOIS metadata for a
+// specified domain has been updated more recently than permitted by the
+// specified thresholds.
+var ErrDomainRecentlyUpdated = errors.New("domain was recently updated")
+
+// ErrDomainRecentlyCreated is returned whenever a specified domain has been
+// created (registered) more recently than permitted by the specified
+// thresholds.
+var ErrDomainRecentlyCreated = errors.New("domain was recently created")
+
 // ErrMissingValue indicates that an expected value was missing.
 var ErrMissingValue = errors.New("missing expected value")
 
+// ErrMissingExpirationDate indicates that the domain expiration date was
+// missing from the WHOIS record or could not be parsed.
+var ErrMissingExpirationDate = errors.New("missing domain expiration date")
+
 // Metadata represents the details for a specified domain, including the
 // parsed WHOIS info, expiration (age) thresholds and parsed date values.
 type Metadata struct {
@@ -42,27 +57,94 @@ type Metadata struct {
 	// Results from parsing the WHOIS info. Most fields are plain text values.
 	WhoisInfo whoisparser.WhoisInfo
 
-	// Name is the plaintext label for this domain.
+	// Name is the plaintext label for this domain. Internationalized domain
+	// names are recorded in ASCII (punycode) form.
 	Name string
 
 	// ExpirationDate indicates when this domain expires.
 	ExpirationDate time.Time
 
 	// UpdatedDate indicates when the domain WHOIS metadata was last updated.
+	// The zero value indicates that the date was not specified.
 	UpdatedDate time.Time
 
-	// CreatedDate indicates when this domain was created/registered.
+	// CreatedDate indicates when this domain was created/registered. The
+	// zero value indicates that the date was not specified.
 	CreatedDate time.Time
 
-	// AgeWarningThreshold is the specified age threshold for when domains
-	// with an expiration less than this value are considered to be in a
-	// WARNING state.
-	AgeWarningThreshold time.Time
-
-	// AgeCRITICALThreshold is the specified age threshold for when domains
-	// with an expiration less than this value are considered to be in a
-	// CRITICAL state.
-	AgeCriticalThreshold time.Time
+	// AgeWarningThreshold is the specified threshold evaluated against the
+	// number of days remaining until expiration to determine whether the
+	// domain is in a WARNING state.
+	AgeWarningThreshold Threshold
+
+	// AgeCriticalThreshold is the specified threshold evaluated against the
+	// number of days remaining until expiration to determine whether the
+	// domain is in a CRITICAL state.
+	AgeCriticalThreshold Threshold
+
+	// UpdatedWarningThreshold is the optional threshold evaluated against
+	// the number of days since the domain WHOIS metadata was last updated to
+	// determine whether the domain is in a WARNING state.
+	UpdatedWarningThreshold Threshold
+
+	// UpdatedCriticalThreshold is the optional threshold evaluated against
+	// the number of days since the domain WHOIS metadata was last updated to
+	// determine whether the domain is in a CRITICAL state.
+	UpdatedCriticalThreshold Threshold
+
+	// CreatedWarningThreshold is the optional threshold evaluated against
+	// the number of days since the domain was created to determine whether
+	// the domain is in a WARNING state.
+	CreatedWarningThreshold Threshold
+
+	// CreatedCriticalThreshold is the optional threshold evaluated against
+	// the number of days since the domain was created to determine whether
+	// the domain is in a CRITICAL state.
+	CreatedCriticalThreshold Threshold
+
+	// ExpectedPrivacy is the optional collection of registrant privacy modes
+	// permitted for the domain. The registrant privacy mode is not evaluated
+	// if not set.
+	ExpectedPrivacy []PrivacyMode
+
+	// PrivacyMismatchState is the service state used when the registrant
+	// privacy mode does not match any of the expected privacy modes.
+	PrivacyMismatchState nagios.ServiceState
+
+	// GracePeriods is the number of days the registry keeps the domain in
+	// each registration lifecycle phase following the expiration date.
+	GracePeriods GracePeriods
+
+	// RequireRegistryLock indicates whether the registry (server) lock
+	// status codes are required to be set for the domain.
+	RequireRegistryLock bool
+
+	// RequireRegistrarLock indicates whether the registrar (client) lock
+	// status codes are required to be set for the domain.
+	RequireRegistrarLock bool
+
+	// MissingLockState is the service state used when required registry or
+	// registrar lock status codes are not set.
+	MissingLockState nagios.ServiceState
+
+	// Clock provides the current time used when evaluating the domain
+	// metadata. The system time is used if not set.
+	Clock Clock
+
+	// DateFormat is the layout and time zone used to display dates.
+	DateFormat DateFormat
+
+	// Lookup describes the retrieval of the registration data. Lookup
+	// performance data metrics are omitted if not set.
+	Lookup *LookupMetrics
+
+	// Metrics is the optional list of performance data metrics generated.
+	// All metrics are generated if not specified.
+	Metrics []Metric
+
+	// Templates is the optional collection of templates used to generate
+	// the one-line summary and report in place of the default wording.
+	Templates *Templates
 }
 
 // parseDateString attempts to parse a given date string using detailed
@@ -90,15 +172,26 @@ func parseDateString(dateString string) (time.Time, error) {
 	)
 }
 
-// NewDomain instantiates a new Metadata type from parsed WHOIS data.
-func NewDomain(whoisInfo whoisparser.WhoisInfo, ageWarning time.Time, ageCritical time.Time) (*Metadata, error) {
+// NewDomain instantiates a new Metadata type from parsed WHOIS data. Only
+// the domain expiration date is required; an error wrapping
+// ErrMissingExpirationDate is returned if it is missing or cannot be parsed.
+// The updated and created dates are optional and are left unset if missing
+// or if they cannot be parsed.
+func NewDomain(whoisInfo whoisparser.WhoisInfo, ageWarning Threshold, ageCritical Threshold) (*Metadata, error) {
 
 	var expirationDate time.Time
-	var updatedDate time.Time
-	var createdDate time.Time
 
 	var err error
 
+	// Parsed WHOIS data may omit domain details entirely (e.g., for
+	// unexpected or heavily redacted registry responses).
+	if whoisInfo.Domain == nil {
+		return nil, fmt.Errorf(
+			"failed to evaluate domain details: %w",
+			ErrMissingValue,
+		)
+	}
+
 	// We attempt to use an already parsed time value as-is first, but if not
 	// set we perform a cursory parsing attempt against the plaintext version
 	// of the date values recorded in the parsed WHOIS record.
@@ -109,96 +202,154 @@ func NewDomain(whoisInfo whoisparser.WhoisInfo, ageWarning time.Time, ageCritica
 	default:
 		expirationDate, err = parseDateString(whoisInfo.Domain.ExpirationDate)
 		if err != nil {
-			return nil, fmt.Errorf(
-				"failed to parse domain expiration date: %w",
-				err,
-			)
+			return nil, fmt.Errorf("%w: %w", ErrMissingExpirationDate, err)
 		}
 	}
 
-	switch {
-	case whoisInfo.Domain.UpdatedDateInTime != nil:
-		updatedDate = *whoisInfo.Domain.UpdatedDateInTime
-	default:
-		updatedDate, err = parseDateString(whoisInfo.Domain.UpdatedDate)
-		if err != nil {
-			return nil, fmt.Errorf(
-				"failed to parse domain updated date: %w",
-				err,
-			)
-		}
-	}
+	// Many registries (particularly ccTLD registries) omit the updated or
+	// created dates or use formats we do not support. Neither is required
+	// to evaluate the domain expiration date.
+	updatedDate := optionalDate(
+		whoisInfo.Domain.UpdatedDateInTime,
+		whoisInfo.Domain.UpdatedDate,
+	)
 
-	switch {
-	case whoisInfo.Domain.CreatedDateInTime != nil:
-		createdDate = *whoisInfo.Domain.CreatedDateInTime
-	default:
-		createdDate, err = parseDateString(whoisInfo.Domain.CreatedDate)
-		if err != nil {
-			return nil, fmt.Errorf(
-				"failed to parse domain creation date: %w",
-				err,
-			)
-		}
+	createdDate := optionalDate(
+		whoisInfo.Domain.CreatedDateInTime,
+		whoisInfo.Domain.CreatedDate,
+	)
+
+	// Record the ASCII (punycode) form of the domain name; some registries
+	// report internationalized domain names using the Unicode form.
+	name := whoisInfo.Domain.Domain
+	if asciiName, err := ASCIIName(name); err == nil {
+		name = asciiName
 	}
 
 	d := Metadata{
 		AgeWarningThreshold:  ageWarning,
 		AgeCriticalThreshold: ageCritical,
 		WhoisInfo:            whoisInfo,
-		Name:                 whoisInfo.Domain.Domain,
+		Name:                 name,
 		ExpirationDate:       expirationDate,
 		UpdatedDate:          updatedDate,
 		CreatedDate:          createdDate,
+		GracePeriods:         DefaultGracePeriods,
 	}
 
 	return &d, nil
 
 }
 
+// optionalDate provides the given already parsed time value or the result of
+// parsing the given plaintext date value. The zero value is returned if
+// neither is usable.
+func optionalDate(parsed *time.Time, dateString string) time.Time {
+	if parsed != nil {
+		return *parsed
+	}
+
+	date, err := parseDateString(dateString)
+	if err != nil {
+		return time.Time{}
+	}
+
+	return date
+}
+
 // OneLineCheckSummary generates a one-line summary of the domain WHOIS check
-// results for display and notification purposes.
+// results for display and notification purposes. The summary template is
+// used if specified.
 func (m Metadata) OneLineCheckSummary() string {
+	if m.Templates != nil && m.Templates.Summary != nil {
+		return m.templatedSummary()
+	}
+
+	return m.defaultSummary()
+}
+
+// defaultSummary generates the one-line summary of the domain WHOIS check
+// results using the default wording.
+func (m Metadata) defaultSummary() string {
 
 	var summary string
 
 	switch {
 	case m.IsExpired():
 		summary = fmt.Sprintf(
-			"%s: %q domain registration EXPIRED %s%s",
+			"%s: %s domain registration EXPIRED %s (%s)",
 			m.ServiceState().Label,
-			m.Name,
-			FormattedExpiration(m.ExpirationDate),
-			nagios.CheckOutputEOL,
+			m.displayName(),
+			FormattedExpiration(m.ExpirationDate, m.Now()),
+			m.phaseSummary(),
 		)
 
 	default:
 
 		summary = fmt.Sprintf(
-			"%s: %q domain registration has %s%s",
+			"%s: %s domain registration has %s",
 			m.ServiceState().Label,
-			m.Name,
-			FormattedExpiration(m.ExpirationDate),
-			nagios.CheckOutputEOL,
+			m.displayName(),
+			FormattedExpiration(m.ExpirationDate, m.Now()),
 		)
 
 	}
 
-	return summary
+	if m.IsRecentlyUpdated() {
+		summary += fmt.Sprintf(
+			", last updated %s",
+			FormattedExpiration(m.UpdatedDate, m.Now()),
+		)
+	}
+
+	if m.IsRecentlyCreated() {
+		summary += fmt.Sprintf(
+			", created %s",
+			FormattedExpiration(m.CreatedDate, m.Now()),
+		)
+	}
+
+	if m.IsPrivacyMismatch() {
+		summary += fmt.Sprintf(
+			", registrant privacy is %s (expected %s)",
+			registrantPrivacy(m),
+			m.expectedPrivacy(),
+		)
+	}
+
+	if m.IsRegistryLockMissing() {
+		summary += ", " + lockSummary("registry", m.MissingRegistryLockStatuses())
+	}
+
+	if m.IsRegistrarLockMissing() {
+		summary += ", " + lockSummary("registrar", m.MissingRegistrarLockStatuses())
+	}
+
+	return summary + nagios.CheckOutputEOL
 
 }
 
 // Report provides an overview of domain details appropriate for display as
 // the LongServiceOutput provided via the web UI or as email or Teams
-// notifications.
+// notifications. The report template is used if specified.
 func (m Metadata) Report() string {
+	if m.Templates != nil && m.Templates.Report != nil {
+		return m.templatedReport()
+	}
+
+	return m.defaultReport()
+}
+
+// defaultReport provides the overview of domain details using the default
+// wording.
+func (m Metadata) defaultReport() string {
 
 	var summary strings.Builder
 
 	_, _ = fmt.Fprintf(
 		&summary,
-		"WHOIS metadata for %q domain:%s%s",
-		m.Name,
+		"WHOIS metadata for %s domain:%s%s",
+		m.displayName(),
 		nagios.CheckOutputEOL,
 		nagios.CheckOutputEOL,
 	)
@@ -213,21 +364,28 @@ func (m Metadata) Report() string {
 	_, _ = fmt.Fprintf(
 		&summary,
 		"* Creation Date: %v%s",
-		m.CreatedDate.Format(DomainDateLayout),
+		m.DateFormat.Format(m.CreatedDate),
 		nagios.CheckOutputEOL,
 	)
 
 	_, _ = fmt.Fprintf(
 		&summary,
 		"* Updated Date: %v%s",
-		m.UpdatedDate.Format(DomainDateLayout),
+		m.DateFormat.Format(m.UpdatedDate),
 		nagios.CheckOutputEOL,
 	)
 
 	_, _ = fmt.Fprintf(
 		&summary,
 		"* Expiration Date: %v%s",
-		m.ExpirationDate.Format(DomainDateLayout),
+		m.DateFormat.Format(m.ExpirationDate),
+		nagios.CheckOutputEOL,
+	)
+
+	_, _ = fmt.Fprintf(
+		&summary,
+		"* Lifecycle Phase: %v%s",
+		m.Phase(),
 		nagios.CheckOutputEOL,
 	)
 
@@ -252,13 +410,22 @@ func (m Metadata) Report() string {
 		nagios.CheckOutputEOL,
 	)
 
+	_, _ = fmt.Fprintf(
+		&summary,
+		"* Registrant Privacy: %v%s",
+		registrantPrivacy(m),
+		nagios.CheckOutputEOL,
+	)
+
+	m.writeLocks(&summary)
+
 	return summary.String()
 
 }
 
 // IsExpired indicates whether the domain expiration date has passed.
 func (m Metadata) IsExpired() bool {
-	return m.ExpirationDate.Before(time.Now())
+	return m.ExpirationDate.Before(m.Now())
 }
 
 // IsExpiring compares the domain's current expiration date against the
@@ -266,10 +433,12 @@ func (m Metadata) IsExpired() bool {
 // about to expire.
 func (m Metadata) IsExpiring() bool {
 
+	expires := m.ExpirationValue()
+
 	switch {
-	case !m.IsExpired() && m.ExpirationDate.Before(m.AgeCriticalThreshold):
+	case !m.IsExpired() && m.AgeCriticalThreshold.Crossed(expires):
 		return true
-	case !m.IsExpired() && m.ExpirationDate.Before(m.AgeWarningThreshold):
+	case !m.IsExpired() && m.AgeWarningThreshold.Crossed(expires):
 		return true
 	}
 
@@ -278,12 +447,25 @@ func (m Metadata) IsExpiring() bool {
 }
 
 // IsWarningState indicates whether a domain's expiration date has been
-// determined to be in a WARNING state. This returns false if the expiration
-// date is in an OK or CRITICAL state, true otherwise.
+// determined to be in a WARNING state. An expired domain which has been
+// auto-renewed by the registry is in a WARNING state. The optional updated
+// and created date thresholds are also evaluated. This returns false if the
+// domain is in an OK or CRITICAL state, true otherwise.
 func (m Metadata) IsWarningState() bool {
-	if !m.IsExpired() &&
-		m.ExpirationDate.Before(m.AgeWarningThreshold) &&
-		!m.ExpirationDate.Before(m.AgeCriticalThreshold) {
+	if m.IsCriticalState() {
+		return false
+	}
+
+	// An auto-renewed domain continues to resolve and will be billed to the
+	// registrar, so is not treated as expired.
+	switch {
+	case m.IsAutoRenewed():
+		return true
+	case m.AgeWarningThreshold.Crossed(m.ExpirationValue()):
+		return true
+	case m.HasUpdatedDate() && m.UpdatedWarningThreshold.Crossed(m.UpdatedValue()):
+		return true
+	case m.HasCreatedDate() && m.CreatedWarningThreshold.Crossed(m.CreatedValue()):
 		return true
 	}
 
@@ -292,10 +474,22 @@ func (m Metadata) IsWarningState() bool {
 }
 
 // IsCriticalState indicates whether a domain's expiration date has been
-// determined to be in a CRITICAL state. This returns false if the expiration
-// date is in an OK or WARNING state, true otherwise.
+// determined to be in a CRITICAL state. Expiration thresholds are not
+// evaluated for an expired domain which has been auto-renewed by the
+// registry. The optional updated and created date thresholds are also
+// evaluated. This returns false if the domain is in
+// an OK or WARNING state, true otherwise.
 func (m Metadata) IsCriticalState() bool {
-	if m.IsExpired() || m.ExpirationDate.Before(m.AgeCriticalThreshold) {
+	autoRenewed := m.IsAutoRenewed()
+
+	switch {
+	case m.IsExpired() && !autoRenewed:
+		return true
+	case !autoRenewed && m.AgeCriticalThreshold.Crossed(m.ExpirationValue()):
+		return true
+	case m.HasUpdatedDate() && m.UpdatedCriticalThreshold.Crossed(m.UpdatedValue()):
+		return true
+	case m.HasCreatedDate() && m.CreatedCriticalThreshold.Crossed(m.CreatedValue()):
 		return true
 	}
 
@@ -303,26 +497,99 @@ func (m Metadata) IsCriticalState() bool {
 
 }
 
+// IsRecentlyUpdated indicates whether the number of days since the domain
+// WHOIS metadata was last updated crosses either of the optional WARNING or
+// CRITICAL updated date thresholds. This returns false if the updated date
+// was not specified.
+func (m Metadata) IsRecentlyUpdated() bool {
+	if !m.HasUpdatedDate() {
+		return false
+	}
+
+	updated := m.UpdatedValue()
+
+	return m.UpdatedWarningThreshold.Crossed(updated) ||
+		m.UpdatedCriticalThreshold.Crossed(updated)
+}
+
+// IsRecentlyCreated indicates whether the number of days since the domain
+// was created crosses either of the optional WARNING or CRITICAL created
+// date thresholds. This returns false if the created date was not
+// specified.
+func (m Metadata) IsRecentlyCreated() bool {
+	if !m.HasCreatedDate() {
+		return false
+	}
+
+	created := m.CreatedValue()
+
+	return m.CreatedWarningThreshold.Crossed(created) ||
+		m.CreatedCriticalThreshold.Crossed(created)
+}
+
+// ExpirationValue provides the number of days (truncated to two decimal
+// places) remaining until the domain expires. If already expired, a negative
+// value is returned. This is the value evaluated against the WARNING and
+// CRITICAL thresholds and emitted as performance data.
+func (m Metadata) ExpirationValue() string {
+	return FormatDays(daysBetween(m.Now(), m.ExpirationDate))
+}
+
+// UpdatedValue provides the number of days (truncated to two decimal places)
+// since the domain WHOIS metadata was last updated. This is the value
+// evaluated against the updated date thresholds and emitted as performance
+// data.
+func (m Metadata) UpdatedValue() string {
+	return FormatDays(daysBetween(m.UpdatedDate, m.Now()))
+}
+
+// CreatedValue provides the number of days (truncated to two decimal places)
+// since the domain was created. This is the value evaluated against the
+// created date thresholds and emitted as performance data.
+func (m Metadata) CreatedValue() string {
+	return FormatDays(daysBetween(m.CreatedDate, m.Now()))
+}
+
+// HasUpdatedDate indicates whether the date the domain WHOIS metadata was
+// last updated was specified.
+func (m Metadata) HasUpdatedDate() bool {
+	return !m.UpdatedDate.IsZero()
+}
+
+// HasCreatedDate indicates whether the date the domain was created was
+// specified.
+func (m Metadata) HasCreatedDate() bool {
+	return !m.CreatedDate.IsZero()
+}
+
 // IsOKState indicates whether a domain's expiration date has been determined
-// to be in an OK state, without expired or expiring domain registration.
+// to be in an OK state, without expired or expiring domain registration (or
+// crossed updated or created date thresholds).
 func (m Metadata) IsOKState() bool {
 	return !m.IsWarningState() && !m.IsCriticalState()
 }
 
 // ServiceState returns the appropriate Service Check Status label and exit
-// code for the evaluated domain expiration metadata.
+// code for the evaluated domain expiration metadata. If an optional check
+// (e.g., the registrant privacy mode or required locks) fails, the state
+// configured for that check is used instead when it is more severe.
 func (m Metadata) ServiceState() nagios.ServiceState {
 
 	var stateLabel string
 	var stateExitCode int
 
+	checkStates := m.failedCheckStates()
+
 	switch {
-	case m.IsCriticalState():
+	case m.IsCriticalState() || slices.Contains(checkStates, nagios.StateCRITICALLabel):
 		stateLabel = nagios.StateCRITICALLabel
 		stateExitCode = nagios.StateCRITICALExitCode
-	case m.IsWarningState():
+	case m.IsWarningState() || slices.Contains(checkStates, nagios.StateWARNINGLabel):
 		stateLabel = nagios.StateWARNINGLabel
 		stateExitCode = nagios.StateWARNINGExitCode
+	case slices.Contains(checkStates, nagios.StateUNKNOWNLabel):
+		stateLabel = nagios.StateUNKNOWNLabel
+		stateExitCode = nagios.StateUNKNOWNExitCode
 	case m.IsOKState():
 		stateLabel = nagios.StateOKLabel
 		stateExitCode = nagios.StateOKExitCode
@@ -338,6 +605,23 @@ func (m Metadata) ServiceState() nagios.ServiceState {
 
 }
 
+// failedCheckStates provides the service state labels configured for the
+// optional checks (e.g., the registrant privacy mode or required locks)
+// which failed.
+func (m Metadata) failedCheckStates() []string {
+	var labels []string
+
+	if m.IsPrivacyMismatch() {
+		labels = append(labels, m.PrivacyMismatchState.Label)
+	}
+
+	if m.IsLockMissing() {
+		labels = append(labels, m.MissingLockState.Label)
+	}
+
+	return labels
+}
+
 // UntilExpiration evaluates the given domain metadata and returns the number
 // of days until the domain expires. If already expired, a negative number is
 // returned indicating how many days the domain is past expiration.
@@ -351,7 +635,7 @@ func UntilExpiration(d *Metadata) (int, error) {
 		)
 	}
 
-	timeRemaining := time.Until(d.ExpirationDate).Hours()
+	timeRemaining := d.ExpirationDate.Sub(d.Now()).Hours()
 
 	// Toss remainder so that we only get the whole number of days
 	daysRemaining := int(math.Trunc(timeRemaining / 24))
@@ -362,16 +646,17 @@ func UntilExpiration(d *Metadata) (int, error) {
 // SinceUpdate evaluates the given domain metadata and returns the number of
 // days since the domain metadata was last updated.
 //
-// An error is returned if the pointer to the given domain metadata is nil.
+// An error is returned if the pointer to the given domain metadata is nil or
+// if the updated date was not specified.
 func SinceUpdate(d *Metadata) (int, error) {
-	if d == nil {
+	if d == nil || !d.HasUpdatedDate() {
 		return 0, fmt.Errorf(
 			"func SinceUpdate: unable to determine days since last update: %w",
 			ErrMissingValue,
 		)
 	}
 
-	timeElapsed := time.Since(d.UpdatedDate).Hours()
+	timeElapsed := d.Now().Sub(d.UpdatedDate).Hours()
 
 	// Toss remainder so that we only get the whole number of days
 	daysSince := int(math.Trunc(timeElapsed / 24))
@@ -382,16 +667,17 @@ func SinceUpdate(d *Metadata) (int, error) {
 // SinceCreation evaluates the given domain metadata and returns the number of
 // days since the domain metadata was first created.
 //
-// An error is returned if the pointer to the given domain metadata is nil.
+// An error is returned if the pointer to the given domain metadata is nil or
+// if the created date was not specified.
 func SinceCreation(d *Metadata) (int, error) {
-	if d == nil {
+	if d == nil || !d.HasCreatedDate() {
 		return 0, fmt.Errorf(
 			"func SinceCreation: unable to determine days since creation: %w",
 			ErrMissingValue,
 		)
 	}
 
-	timeElapsed := time.Since(d.CreatedDate).Hours()
+	timeElapsed := d.Now().Sub(d.CreatedDate).Hours()
 
 	// Toss remainder so that we only get the whole number of days
 	daysSince := int(math.Trunc(timeElapsed / 24))
@@ -400,15 +686,17 @@ func SinceCreation(d *Metadata) (int, error) {
 }
 
 // FormattedExpiration receives a Time value and converts it to a string
-// representing the largest useful whole units of time in days and hours. For
+// representing the largest useful whole units of time in days and hours
+// relative to the given current time (e.g., the time provided by the Clock
+// used to evaluate domain metadata). For
 // example, if a domain has 1 year, 2 days and 3 hours remaining until
 // expiration, this function will return the string '367d 3h remaining', but
 // if only 3 hours remain then '3h remaining' will be returned. If a domain
 // registration has expired, the 'ago' suffix will be used instead. For
 // example, if a domain has expired 3 hours ago, '3h ago' will be returned.
-func FormattedExpiration(expireTime time.Time) string {
+func FormattedExpiration(expireTime time.Time, now time.Time) string {
 
-	timeRemaining := time.Until(expireTime).Hours()
+	timeRemaining := expireTime.Sub(now).Hours()
 
 	var timeExpired bool
 	var formattedTimeRemainingStr string
@@ -480,6 +768,39 @@ func registrantName(m Metadata) string {
 	return defaultWhoISPlaceholderValue
 }
 
+// registrantPrivacy classifies the registrant details from the WhoIS record.
+// Details naming a known privacy or proxy service are classified as proxied.
+// Details with a registrant name, organization or email which is specified
+// and not redacted are classified as public. Otherwise (including when
+// registrant details are omitted entirely) the details are classified as
+// redacted.
+func registrantPrivacy(m Metadata) PrivacyMode {
+	registrant := m.WhoisInfo.Registrant
+	if registrant == nil {
+		return PrivacyModeRedacted
+	}
+
+	identifying := []string{
+		registrant.Name,
+		registrant.Organization,
+		registrant.Email,
+	}
+
+	for _, value := range identifying {
+		if IsPrivacyProxy(value) {
+			return PrivacyModeProxy
+		}
+	}
+
+	for _, value := range identifying {
+		if strings.TrimSpace(value) != "" && !IsRedacted(value) {
+			return PrivacyModePublic
+		}
+	}
+
+	return PrivacyModeRedacted
+}
+
 // registrantEmail provides the registrant email value from the WhoIS record
 // or the fallback/placeholder value for the field.
 func registrantEmail(m Metadata) string {
@@ -489,3 +810,15 @@ func registrantEmail(m Metadata) string {
 
 	return defaultWhoISPlaceholderValue
 }
+
+// RegistrarName provides the registrar name value from the WhoIS record or
+// the fallback/placeholder value for the field.
+func (m Metadata) RegistrarName() string {
+	return registrarName(m)
+}
+
+// DomainStatus provides the comma-separated domain status values from the
+// WhoIS record or the fallback/placeholder value for the field.
+func (m Metadata) DomainStatus() string {
+	return domainStatus(m)
+}
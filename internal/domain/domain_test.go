@@ -0,0 +1,143 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-whois
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package domain
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/atc0005/go-nagios"
+	whoisparser "github.com/likexian/whois-parser"
+)
+
+// TestNewDomainOptionalDates asserts that only a missing domain expiration
+// date prevents domain metadata from being evaluated and that missing
+// updated or created dates are reported as unspecified.
+func TestNewDomainOptionalDates(t *testing.T) {
+	t.Parallel()
+
+	warning, critical := testThresholds(t)
+
+	recent, err := ParseThreshold("20000")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		expiration  string
+		updated     string
+		created     string
+		wantErr     error
+		wantUpdated bool
+		wantCreated bool
+		wantReport  []string
+	}{
+		"all dates": {
+			expiration:  "2027-08-12T04:00:00Z",
+			updated:     "2025-07-09T15:04:11Z",
+			created:     "1998-08-13T04:00:00Z",
+			wantUpdated: true,
+			wantCreated: true,
+		},
+		"missing updated date": {
+			expiration:  "2027-08-12",
+			created:     "1998-08-13",
+			wantCreated: true,
+			wantReport:  []string{"* Updated Date: unspecified"},
+		},
+		"unsupported updated date format": {
+			expiration:  "2027-08-12",
+			updated:     "2025/06/01 01:05:03 (JST)",
+			created:     "1998-08-13",
+			wantCreated: true,
+			wantReport:  []string{"* Updated Date: unspecified"},
+		},
+		"missing updated and created dates": {
+			expiration: "2027-08-12",
+			wantReport: []string{
+				"* Creation Date: unspecified",
+				"* Updated Date: unspecified",
+			},
+		},
+		"missing expiration date": {
+			updated: "2025-07-09",
+			created: "1998-08-13",
+			wantErr: ErrMissingExpirationDate,
+		},
+		"unsupported expiration date format": {
+			expiration: "20270812",
+			wantErr:    ErrMissingExpirationDate,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			info := whoisparser.WhoisInfo{
+				Domain: &whoisparser.Domain{
+					Domain:         "example.com",
+					ExpirationDate: tt.expiration,
+					UpdatedDate:    tt.updated,
+					CreatedDate:    tt.created,
+				},
+			}
+
+			m, err := NewDomain(info, warning, critical)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("want error %v, got %v", tt.wantErr, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// Thresholds which would be crossed by any specified date are
+			// used to assert that unspecified dates are not evaluated.
+			m.UpdatedCriticalThreshold = recent
+			m.CreatedCriticalThreshold = recent
+			m.Clock = FixedClock(goldenNow)
+
+			if got := m.HasUpdatedDate(); got != tt.wantUpdated {
+				t.Errorf("want updated date specified %t, got %t", tt.wantUpdated, got)
+			}
+
+			if got := m.HasCreatedDate(); got != tt.wantCreated {
+				t.Errorf("want created date specified %t, got %t", tt.wantCreated, got)
+			}
+
+			if got := m.IsRecentlyUpdated(); got != tt.wantUpdated {
+				t.Errorf("want recently updated %t, got %t", tt.wantUpdated, got)
+			}
+
+			if got := m.IsRecentlyCreated(); got != tt.wantCreated {
+				t.Errorf("want recently created %t, got %t", tt.wantCreated, got)
+			}
+
+			if _, err := SinceUpdate(m); tt.wantUpdated == errors.Is(err, ErrMissingValue) {
+				t.Errorf("unexpected SinceUpdate error: %v", err)
+			}
+
+			if !tt.wantUpdated && !tt.wantCreated && m.ServiceState().Label != nagios.StateOKLabel {
+				t.Errorf("want %s state, got %s", nagios.StateOKLabel, m.ServiceState().Label)
+			}
+
+			report := m.Report()
+			for _, want := range tt.wantReport {
+				if !strings.Contains(report, want) {
+					t.Errorf("\nwant report containing %q\ngot:\n%s", want, report)
+				}
+			}
+		})
+	}
+}
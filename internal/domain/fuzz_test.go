@@ -0,0 +1,258 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-whois
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package domain
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/atc0005/check-whois/internal/lookup"
+	"github.com/atc0005/go-nagios"
+	whoisparser "github.com/likexian/whois-parser"
+)
+
+// testThresholds provides the default expiration thresholds used by tests
+// and fuzz targets.
+func testThresholds(t testing.TB) (Threshold, Threshold) {
+	t.Helper()
+
+	warning, err := ParseThreshold("30")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	critical, err := ParseThreshold("15")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return warning, critical
+}
+
+// addCorpusSeeds adds the raw WHOIS responses in the test corpus as seed
+// inputs for the given fuzz target.
+func addCorpusSeeds(f *testing.F) {
+	f.Helper()
+
+	files, err := filepath.Glob(filepath.Join(corpusDir, "*.txt"))
+	if err != nil {
+		f.Fatal(err)
+	}
+
+	for _, file := range files {
+		raw, err := os.ReadFile(filepath.Clean(file))
+		if err != nil {
+			f.Fatal(err)
+		}
+
+		f.Add(string(raw))
+	}
+}
+
+// assertConsistentState asserts that the given domain metadata is in
+// exactly one of the OK, WARNING or CRITICAL states and that the service
+// state agrees.
+func assertConsistentState(t *testing.T, m Metadata) {
+	t.Helper()
+
+	ok, warning, critical := m.IsOKState(), m.IsWarningState(), m.IsCriticalState()
+
+	var count int
+	for _, state := range []bool{ok, warning, critical} {
+		if state {
+			count++
+		}
+	}
+
+	if count != 1 {
+		t.Fatalf(
+			"want exactly one state, got OK=%t WARNING=%t CRITICAL=%t",
+			ok, warning, critical,
+		)
+	}
+
+	var want string
+	switch {
+	case ok:
+		want = nagios.StateOKLabel
+	case warning:
+		want = nagios.StateWARNINGLabel
+	case critical:
+		want = nagios.StateCRITICALLabel
+	}
+
+	if got := m.ServiceState().Label; got != want {
+		t.Fatalf("want service state %s, got %s", want, got)
+	}
+}
+
+// assertReports asserts that the one-line summary and report can be
+// generated for the given domain metadata.
+func assertReports(t *testing.T, m Metadata) {
+	t.Helper()
+
+	summary := m.OneLineCheckSummary()
+	if !strings.HasPrefix(summary, m.ServiceState().Label+": ") {
+		t.Fatalf("summary %q does not begin with the service state", summary)
+	}
+
+	if report := m.Report(); !strings.Contains(report, m.displayName()) {
+		t.Fatalf("report does not include the domain name:\n%s", report)
+	}
+}
+
+// FuzzParseDateString asserts that parsing arbitrary date strings does not
+// panic and that the zero time is returned along with any error.
+func FuzzParseDateString(f *testing.F) {
+	for _, seed := range []string{
+		"",
+		"2026-01-15",
+		"2026-01-15T00:00:00Z",
+		"2027-08-11T21:00:00-0700",
+		"1998-08-13T04:00:00.0Z",
+		"20260301",
+		"2026/05/31",
+		"2025/06/01 01:05:03 (JST)",
+		"26-Nov-1999",
+		"0001-01-01",
+		"9999-12-31T23:59:59+14:00",
+	} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, input string) {
+		date, err := parseDateString(input)
+		if err != nil {
+			if !date.IsZero() {
+				t.Fatalf("want zero time with error, got %v", date)
+			}
+
+			return
+		}
+
+		// The date must survive formatting for display.
+		_ = date.Format(DomainDateLayout)
+	})
+}
+
+// FuzzNewDomain asserts that domain metadata created from arbitrary date
+// values and domain names can be evaluated and reported without panicking
+// and that the evaluated state is consistent.
+func FuzzNewDomain(f *testing.F) {
+	f.Add("example.com", "2027-08-12T04:00:00Z", "2025-07-09T15:04:11Z", "1998-08-13T04:00:00Z", false)
+	f.Add("xn--bcher-kva.com", "2026-01-22", "2026-01-14", "2026-01-10", false)
+	f.Add("bücher.com", "2026-01-10T09:58:47Z", "", "2020-01-10", false)
+	f.Add("", "", "", "", true)
+	f.Add("example.jp", "2026/05/31", "2025/06/01 01:05:03 (JST)", "2001/05/08", false)
+
+	warning, critical := testThresholds(f)
+	now := FixedClock(goldenNow)
+
+	f.Fuzz(func(t *testing.T, name string, expiration string, updated string, created string, nilDomain bool) {
+		info := whoisparser.WhoisInfo{
+			Domain: &whoisparser.Domain{
+				Domain:         name,
+				ExpirationDate: expiration,
+				UpdatedDate:    updated,
+				CreatedDate:    created,
+			},
+		}
+
+		if nilDomain {
+			info.Domain = nil
+		}
+
+		m, err := NewDomain(info, warning, critical)
+		switch {
+		case nilDomain && !errors.Is(err, ErrMissingValue):
+			t.Fatalf("want missing value error for nil domain details, got %v", err)
+		case err != nil:
+			return
+		}
+
+		m.Clock = now
+
+		assertConsistentState(t, *m)
+		assertReports(t, *m)
+	})
+}
+
+// FuzzReport asserts that the report helpers handle arbitrary WHOIS field
+// values, dates and missing WHOIS record sections without panicking.
+func FuzzReport(f *testing.F) {
+	f.Add("example.com", "clientTransferProhibited", "Example Registrar, LLC", "REDACTED FOR PRIVACY", "hostmaster@example.com", int64(1786507200), int64(1752073451), int64(903067200), true)
+	f.Add("", "", "", "", "", int64(0), int64(0), int64(0), false)
+	f.Add("xn--bcher-kva.com", "addPeriod", "", "", "", int64(-62135596800), int64(253402300799), int64(1768435200), true)
+
+	warning, critical := testThresholds(f)
+	now := FixedClock(goldenNow)
+
+	f.Fuzz(func(t *testing.T, name string, status string, registrar string, registrant string, email string, expiration int64, updated int64, created int64, sections bool) {
+		m := Metadata{
+			Name:                     name,
+			ExpirationDate:           time.Unix(expiration, 0).UTC(),
+			UpdatedDate:              time.Unix(updated, 0).UTC(),
+			CreatedDate:              time.Unix(created, 0).UTC(),
+			AgeWarningThreshold:      warning,
+			AgeCriticalThreshold:     critical,
+			UpdatedWarningThreshold:  warning,
+			CreatedCriticalThreshold: critical,
+			Clock:                    now,
+		}
+
+		if sections {
+			m.WhoisInfo = whoisparser.WhoisInfo{
+				Domain:     &whoisparser.Domain{Domain: name, Status: strings.Split(status, ",")},
+				Registrar:  &whoisparser.Contact{Name: registrar},
+				Registrant: &whoisparser.Contact{Name: registrant, Email: email},
+			}
+		}
+
+		assertConsistentState(t, m)
+		assertReports(t, m)
+
+		_ = m.RegistrarName()
+		_ = FormattedExpiration(m.ExpirationDate, m.Now())
+	})
+}
+
+// FuzzWHOISPipeline asserts that arbitrary raw WHOIS responses can be parsed,
+// evaluated and reported without panicking and that the evaluated state is
+// consistent. The test corpus of raw WHOIS responses is used as seed input.
+// Parsing is performed as by the plugin, guarding against WHOIS parser
+// failures for malformed responses.
+func FuzzWHOISPipeline(f *testing.F) {
+	addCorpusSeeds(f)
+
+	warning, critical := testThresholds(f)
+	now := FixedClock(goldenNow)
+
+	f.Fuzz(func(t *testing.T, raw string) {
+		info, err := lookup.Parse(lookup.RawResult{
+			Format: lookup.FormatWHOIS,
+			Data:   raw,
+		})
+		if err != nil {
+			return
+		}
+
+		m, err := NewDomain(info, warning, critical)
+		if err != nil {
+			return
+		}
+
+		m.Clock = now
+
+		assertConsistentState(t, *m)
+		assertReports(t, *m)
+	})
+}
@@ -0,0 +1,166 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-whois
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package domain
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	whoisparser "github.com/likexian/whois-parser"
+)
+
+// update indicates whether golden files should be refreshed using the
+// current output instead of being compared against it. Use `make golden` or
+// `go test ./internal/domain/ -run Golden -update` to refresh golden files
+// after reviewing parser or report changes.
+var update = flag.Bool("update", false, "refresh golden files")
+
+// corpusDir is the directory containing the anonymized raw WHOIS responses
+// used as test input.
+const corpusDir string = "testdata/whois"
+
+// goldenDir is the directory containing the expected output for each raw
+// WHOIS response in the corpus.
+const goldenDir string = "testdata/golden"
+
+// goldenNow is the fixed time used when evaluating the corpus so that the
+// expected output does not change over time.
+var goldenNow = time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC)
+
+// corpusFiles provides the paths of the raw WHOIS responses in the corpus.
+func corpusFiles(t *testing.T) []string {
+	t.Helper()
+
+	files, err := filepath.Glob(filepath.Join(corpusDir, "*.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(files) == 0 {
+		t.Fatalf("no WHOIS responses found in %s", corpusDir)
+	}
+
+	return files
+}
+
+// goldenMetadata parses the given raw WHOIS response and evaluates it using
+// the fixed clock and the default thresholds. The result of each stage is
+// written to the given builder.
+func goldenMetadata(t *testing.T, raw string, out *strings.Builder) {
+	t.Helper()
+
+	warning, err := ParseThreshold("30")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	critical, err := ParseThreshold("15")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	createdWarning, err := ParseThreshold("30")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	info, err := whoisparser.Parse(raw)
+	if err != nil {
+		_, _ = fmt.Fprintf(out, "== Parse\nerror: %v\n", err)
+
+		return
+	}
+
+	m, err := NewDomain(info, warning, critical)
+
+	_, _ = fmt.Fprintln(out, "== NewDomain")
+	if err != nil {
+		_, _ = fmt.Fprintf(out, "error: %v\n", err)
+
+		return
+	}
+
+	m.CreatedWarningThreshold = createdWarning
+	m.Clock = FixedClock(goldenNow)
+
+	_, _ = fmt.Fprintf(out, "Name: %s\n", m.Name)
+	_, _ = fmt.Fprintf(out, "ExpirationDate: %s\n", m.ExpirationDate.Format(time.RFC3339))
+	_, _ = fmt.Fprintf(out, "UpdatedDate: %s\n", goldenDate(m.UpdatedDate))
+	_, _ = fmt.Fprintf(out, "CreatedDate: %s\n", goldenDate(m.CreatedDate))
+	_, _ = fmt.Fprintf(out, "State: %s\n", m.ServiceState().Label)
+
+	_, _ = fmt.Fprintf(out, "== OneLineCheckSummary\n%s", m.OneLineCheckSummary())
+	_, _ = fmt.Fprintf(out, "== Report\n%s", m.Report())
+	_, _ = fmt.Fprintf(out, "== VerboseReport (contacts)\n%s", strings.TrimPrefix(m.VerboseReport(), m.Report()))
+}
+
+// goldenDate formats the given optional date for inclusion in golden files.
+func goldenDate(date time.Time) string {
+	if date.IsZero() {
+		return "unspecified"
+	}
+
+	return date.Format(time.RFC3339)
+}
+
+// checkGolden compares the given output against the golden file for the
+// given corpus file, refreshing the golden file instead if requested.
+func checkGolden(t *testing.T, corpusFile string, got string) {
+	t.Helper()
+
+	name := strings.TrimSuffix(filepath.Base(corpusFile), filepath.Ext(corpusFile))
+	goldenFile := filepath.Join(goldenDir, name+".golden")
+
+	if *update {
+		if err := os.MkdirAll(goldenDir, 0o750); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := os.WriteFile(goldenFile, []byte(got), 0o600); err != nil {
+			t.Fatal(err)
+		}
+
+		return
+	}
+
+	want, err := os.ReadFile(filepath.Clean(goldenFile))
+	if err != nil {
+		t.Fatalf("failed to read golden file (use -update to create): %v", err)
+	}
+
+	if got != string(want) {
+		t.Errorf("output does not match %s\nwant:\n%s\ngot:\n%s", goldenFile, want, got)
+	}
+}
+
+// TestGoldenWHOISCorpus asserts that each raw WHOIS response in the corpus
+// is parsed and reported as recorded in the matching golden file.
+func TestGoldenWHOISCorpus(t *testing.T) {
+	t.Parallel()
+
+	for _, file := range corpusFiles(t) {
+		t.Run(filepath.Base(file), func(t *testing.T) {
+			t.Parallel()
+
+			raw, err := os.ReadFile(filepath.Clean(file))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var got strings.Builder
+			goldenMetadata(t, string(raw), &got)
+
+			checkGolden(t, file, got.String())
+		})
+	}
+}
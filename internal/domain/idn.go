@@ -0,0 +1,88 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-whois
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/idna"
+)
+
+// ErrInvalidDomainName indicates that a given domain name is malformed.
+var ErrInvalidDomainName = errors.New("invalid domain name")
+
+// idnaProfile is the IDNA2008 profile (using UTS #46 mapping) used to
+// validate and convert domain names prior to performing WHOIS queries. Unlike
+// the stock idna.Lookup profile, label and overall name lengths are also
+// verified.
+var idnaProfile = idna.New(
+	idna.MapForLookup(),
+	idna.BidiRule(),
+	idna.VerifyDNSLength(true),
+	idna.Transitional(false),
+)
+
+// ASCIIName validates the given domain name and returns the ASCII (punycode)
+// form of the name suitable for use with WHOIS queries. Internationalized
+// domain names (e.g., "münchen.de") are converted using IDNA2008/UTS #46
+// processing rules, ASCII names are lowercased. An error is returned if the
+// name contains malformed labels.
+func ASCIIName(name string) (string, error) {
+	name = strings.TrimSuffix(strings.TrimSpace(name), ".")
+
+	if name == "" {
+		return "", fmt.Errorf(
+			"%w: empty name: %w",
+			ErrInvalidDomainName,
+			ErrMissingValue,
+		)
+	}
+
+	asciiName, err := idnaProfile.ToASCII(name)
+	if err != nil {
+		return "", fmt.Errorf(
+			"%w %q: %w",
+			ErrInvalidDomainName,
+			name,
+			err,
+		)
+	}
+
+	return asciiName, nil
+}
+
+// UnicodeName returns the Unicode form of the given domain name for display
+// purposes. The name is returned as-is if conversion fails.
+func UnicodeName(name string) string {
+	unicodeName, err := idna.Display.ToUnicode(name)
+	if err != nil {
+		return name
+	}
+
+	return unicodeName
+}
+
+// IsIDN indicates whether the domain is an internationalized domain name
+// (i.e., whether the Unicode and ASCII forms of the name differ).
+func (m Metadata) IsIDN() bool {
+	return UnicodeName(m.Name) != m.Name
+}
+
+// displayName provides the quoted domain name for display purposes. Both the
+// Unicode and ASCII (punycode) forms are provided for internationalized
+// domain names.
+func (m Metadata) displayName() string {
+	if !m.IsIDN() {
+		return strconv.Quote(m.Name)
+	}
+
+	return fmt.Sprintf("%q (%s)", UnicodeName(m.Name), m.Name)
+}
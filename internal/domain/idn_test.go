@@ -0,0 +1,80 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-whois
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestASCIIName asserts that Unicode and ASCII domain names are normalized
+// to their ASCII (punycode) form and that malformed names are rejected.
+func TestASCIIName(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		"ASCII name":                {input: "example.com", want: "example.com"},
+		"ASCII name with uppercase": {input: "EXAMPLE.com", want: "example.com"},
+		"trailing dot":              {input: "example.com.", want: "example.com"},
+		"Unicode name":              {input: "münchen.de", want: "xn--mnchen-3ya.de"},
+		"Unicode name uppercase":    {input: "MÜNCHEN.de", want: "xn--mnchen-3ya.de"},
+		"punycode name":             {input: "xn--mnchen-3ya.de", want: "xn--mnchen-3ya.de"},
+		"empty name":                {input: "", wantErr: true},
+		"empty label":               {input: "example..com", wantErr: true},
+		"leading hyphen":            {input: "-example.com", wantErr: true},
+		"invalid punycode":          {input: "xn--zz.com", wantErr: true},
+		"embedded space":            {input: "exa mple.com", wantErr: true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ASCIIName(tt.input)
+			switch {
+			case tt.wantErr && !errors.Is(err, ErrInvalidDomainName):
+				t.Fatalf("want ErrInvalidDomainName for input %q, got %v", tt.input, err)
+			case !tt.wantErr && err != nil:
+				t.Fatalf("unexpected error for input %q: %v", tt.input, err)
+			}
+
+			if got != tt.want {
+				t.Errorf("\nwant %q\ngot %q", tt.want, got)
+			}
+		})
+	}
+}
+
+// TestDisplayNameIncludesBothIDNForms asserts that both the Unicode and
+// ASCII forms of an internationalized domain name are displayed.
+func TestDisplayNameIncludesBothIDNForms(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		name string
+		want string
+	}{
+		"ASCII name": {name: "example.com", want: `"example.com"`},
+		"IDN name":   {name: "xn--mnchen-3ya.de", want: `"münchen.de" (xn--mnchen-3ya.de)`},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			m := Metadata{Name: tt.name}
+			if got := m.displayName(); got != tt.want {
+				t.Errorf("\nwant %q\ngot %q", tt.want, got)
+			}
+		})
+	}
+}
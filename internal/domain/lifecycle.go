@@ -0,0 +1,230 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-whois
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidGracePeriods indicates that grace period lengths could not be
+// parsed.
+var ErrInvalidGracePeriods = errors.New("invalid grace period lengths")
+
+// Phase identifies the registration lifecycle phase of a domain.
+type Phase string
+
+// Supported registration lifecycle phases.
+const (
+
+	// PhaseActive indicates that the domain expiration date has not passed.
+	PhaseActive Phase = "active"
+
+	// PhaseAutoRenewGrace indicates that the domain expiration date has
+	// passed and the domain is within the auto-renew grace period. The
+	// domain continues to resolve and may still be renewed by the
+	// registrar.
+	PhaseAutoRenewGrace Phase = "autoRenewPeriod"
+
+	// PhaseRedemption indicates that the domain has been deleted by the
+	// registrar and may only be restored (usually for a fee) during the
+	// redemption grace period.
+	PhaseRedemption Phase = "redemptionPeriod"
+
+	// PhasePendingDelete indicates that the domain can no longer be
+	// restored and will be released for registration by anyone.
+	PhasePendingDelete Phase = "pendingDelete"
+)
+
+// EPP status codes indicating the registration lifecycle phase of a domain.
+const (
+	StatusAutoRenewPeriod  string = "autoRenewPeriod"
+	StatusRedemptionPeriod string = "redemptionPeriod"
+	StatusPendingDelete    string = "pendingDelete"
+)
+
+// GracePeriods is the number of days a registry keeps a domain in each
+// registration lifecycle phase following the expiration date.
+type GracePeriods struct {
+
+	// AutoRenew is the number of days in the auto-renew grace period.
+	AutoRenew int
+
+	// Redemption is the number of days in the redemption grace period.
+	Redemption int
+
+	// PendingDelete is the number of days in the pending delete period.
+	PendingDelete int
+}
+
+// DefaultGracePeriods is the grace period lengths used by most gTLD
+// registries: a 45 day auto-renew grace period, a 30 day redemption grace
+// period and a 5 day pending delete period.
+var DefaultGracePeriods = GracePeriods{
+	AutoRenew:     45,
+	Redemption:    30,
+	PendingDelete: 5,
+}
+
+// ParseGracePeriods parses the auto-renew, redemption and pending delete
+// grace period lengths (in days) from the given value using the form
+// autoRenew:redemption:pendingDelete (e.g., 45:30:5).
+func ParseGracePeriods(value string) (GracePeriods, error) {
+	fields := strings.Split(strings.TrimSpace(value), ":")
+	if len(fields) != 3 {
+		return GracePeriods{}, fmt.Errorf(
+			"%w: %q; expected autoRenew:redemption:pendingDelete days (e.g., 45:30:5)",
+			ErrInvalidGracePeriods,
+			value,
+		)
+	}
+
+	days := make([]int, 0, len(fields))
+	for _, field := range fields {
+		n, err := strconv.Atoi(strings.TrimSpace(field))
+		if err != nil || n < 0 {
+			return GracePeriods{}, fmt.Errorf(
+				"%w: %q; lengths must be whole numbers of days",
+				ErrInvalidGracePeriods,
+				value,
+			)
+		}
+
+		days = append(days, n)
+	}
+
+	return GracePeriods{
+		AutoRenew:     days[0],
+		Redemption:    days[1],
+		PendingDelete: days[2],
+	}, nil
+}
+
+// String provides the grace period lengths in the form accepted by
+// ParseGracePeriods.
+func (g GracePeriods) String() string {
+	return fmt.Sprintf("%d:%d:%d", g.AutoRenew, g.Redemption, g.PendingDelete)
+}
+
+// GracePeriodTable maps public suffixes (e.g., "com" or "co.uk") to grace
+// period lengths. The "*" entry, if present, replaces DefaultGracePeriods
+// for public suffixes not listed.
+type GracePeriodTable map[string]GracePeriods
+
+// For provides the grace period lengths for the given (ASCII) domain name
+// using the longest matching public suffix listed in the table.
+func (t GracePeriodTable) For(name string) GracePeriods {
+	labels := strings.Split(strings.ToLower(strings.TrimSuffix(name, ".")), ".")
+
+	for i := 1; i < len(labels); i++ {
+		if periods, ok := t[strings.Join(labels[i:], ".")]; ok {
+			return periods
+		}
+	}
+
+	if periods, ok := t["*"]; ok {
+		return periods
+	}
+
+	return DefaultGracePeriods
+}
+
+// days provides the given number of days as a duration.
+func days(n int) time.Duration {
+	return time.Duration(n) * 24 * time.Hour
+}
+
+// Phase provides the registration lifecycle phase of the domain. The
+// lifecycle status codes set by the registry are used if present, otherwise
+// the phase is estimated using the time elapsed since the expiration date
+// and the grace period lengths.
+func (m Metadata) Phase() Phase {
+	if !m.IsExpired() {
+		return PhaseActive
+	}
+
+	switch {
+	case m.HasStatus(StatusPendingDelete):
+		return PhasePendingDelete
+	case m.HasStatus(StatusRedemptionPeriod):
+		return PhaseRedemption
+	case m.HasStatus(StatusAutoRenewPeriod):
+		return PhaseAutoRenewGrace
+	}
+
+	elapsed := m.Now().Sub(m.ExpirationDate)
+
+	switch {
+	case elapsed < days(m.GracePeriods.AutoRenew):
+		return PhaseAutoRenewGrace
+	case elapsed < days(m.GracePeriods.AutoRenew+m.GracePeriods.Redemption):
+		return PhaseRedemption
+	default:
+		return PhasePendingDelete
+	}
+}
+
+// IsAutoRenewed indicates whether the domain expiration date has passed and
+// the registry has auto-renewed the domain (as indicated by the
+// autoRenewPeriod status code). The domain continues to resolve and will be
+// billed to the registrar unless deleted.
+func (m Metadata) IsAutoRenewed() bool {
+	return m.Phase() == PhaseAutoRenewGrace && m.HasStatus(StatusAutoRenewPeriod)
+}
+
+// LostDate provides the (estimated) date the domain is released by the
+// registry following expiration, after which the registration is lost. The
+// estimate is adjusted if the registry indicates that the domain has
+// entered a later lifecycle phase than the grace period lengths suggest.
+func (m Metadata) LostDate() time.Time {
+	g := m.GracePeriods
+
+	lost := m.ExpirationDate.Add(days(g.AutoRenew + g.Redemption + g.PendingDelete))
+
+	var latest time.Time
+	switch {
+	case !m.IsExpired():
+		return lost
+	case m.HasStatus(StatusPendingDelete):
+		latest = m.Now().Add(days(g.PendingDelete))
+	case m.HasStatus(StatusRedemptionPeriod):
+		latest = m.Now().Add(days(g.Redemption + g.PendingDelete))
+	default:
+		return lost
+	}
+
+	if latest.Before(lost) {
+		return latest
+	}
+
+	return lost
+}
+
+// phaseSummary provides a description of the registration lifecycle phase
+// and the time remaining before the domain is lost for inclusion in the
+// one-line summary.
+func (m Metadata) phaseSummary() string {
+	lost := m.LostDate()
+
+	if lost.After(m.Now()) {
+		return fmt.Sprintf(
+			"%s phase, %s before the domain is lost",
+			m.Phase(),
+			FormattedExpiration(lost, m.Now()),
+		)
+	}
+
+	return fmt.Sprintf(
+		"%s phase, domain lost %s",
+		m.Phase(),
+		FormattedExpiration(lost, m.Now()),
+	)
+}
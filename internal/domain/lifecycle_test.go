@@ -0,0 +1,183 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-whois
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package domain
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/atc0005/go-nagios"
+	whoisparser "github.com/likexian/whois-parser"
+)
+
+// TestPhase asserts that the registration lifecycle phase, the date the
+// domain is lost and the service state are determined using the lifecycle
+// status codes or the grace period lengths.
+func TestPhase(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		expiration  string
+		statuses    []string
+		periods     GracePeriods
+		wantPhase   Phase
+		wantLost    time.Time
+		wantState   string
+		wantSummary string
+	}{
+		"active": {
+			expiration: "2027-08-12T00:00:00Z",
+			statuses:   []string{StatusAutoRenewPeriod},
+			periods:    DefaultGracePeriods,
+			wantPhase:  PhaseActive,
+			wantLost:   time.Date(2027, 10, 31, 0, 0, 0, 0, time.UTC),
+			wantState:  nagios.StateOKLabel,
+		},
+		"auto-renewed": {
+			expiration:  "2026-01-10T00:00:00Z",
+			statuses:    []string{"clientTransferProhibited", "autoRenewPeriod https://icann.org/epp#autoRenewPeriod"},
+			periods:     DefaultGracePeriods,
+			wantPhase:   PhaseAutoRenewGrace,
+			wantLost:    time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC),
+			wantState:   nagios.StateWARNINGLabel,
+			wantSummary: "(autoRenewPeriod phase, 75d 0h remaining before the domain is lost)",
+		},
+		"estimated auto-renew grace": {
+			expiration: "2026-01-10T00:00:00Z",
+			statuses:   []string{"clientHold"},
+			periods:    DefaultGracePeriods,
+			wantPhase:  PhaseAutoRenewGrace,
+			wantLost:   time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC),
+			wantState:  nagios.StateCRITICALLabel,
+		},
+		"estimated redemption": {
+			expiration: "2025-11-05T00:00:00Z",
+			periods:    DefaultGracePeriods,
+			wantPhase:  PhaseRedemption,
+			wantLost:   time.Date(2026, 1, 24, 0, 0, 0, 0, time.UTC),
+			wantState:  nagios.StateCRITICALLabel,
+		},
+		"redemption status": {
+			expiration: "2026-01-10T00:00:00Z",
+			statuses:   []string{StatusRedemptionPeriod},
+			periods:    DefaultGracePeriods,
+			wantPhase:  PhaseRedemption,
+			wantLost:   time.Date(2026, 2, 19, 0, 0, 0, 0, time.UTC),
+			wantState:  nagios.StateCRITICALLabel,
+		},
+		"pending delete status": {
+			expiration:  "2026-01-10T00:00:00Z",
+			statuses:    []string{StatusPendingDelete, StatusRedemptionPeriod},
+			periods:     DefaultGracePeriods,
+			wantPhase:   PhasePendingDelete,
+			wantLost:    time.Date(2026, 1, 20, 0, 0, 0, 0, time.UTC),
+			wantState:   nagios.StateCRITICALLabel,
+			wantSummary: "(pendingDelete phase, 5d 0h remaining before the domain is lost)",
+		},
+		"lost": {
+			expiration:  "2025-12-01T00:00:00Z",
+			periods:     GracePeriods{AutoRenew: 30, Redemption: 0, PendingDelete: 0},
+			wantPhase:   PhasePendingDelete,
+			wantLost:    time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC),
+			wantState:   nagios.StateCRITICALLabel,
+			wantSummary: "(pendingDelete phase, domain lost 15d 0h ago)",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			warning, critical := testThresholds(t)
+
+			info := whoisparser.WhoisInfo{
+				Domain: &whoisparser.Domain{
+					Domain:         "example.com",
+					ExpirationDate: tt.expiration,
+					Status:         tt.statuses,
+				},
+			}
+
+			m, err := NewDomain(info, warning, critical)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			m.GracePeriods = tt.periods
+			m.Clock = FixedClock(goldenNow)
+
+			if got := m.Phase(); got != tt.wantPhase {
+				t.Errorf("want phase %s, got %s", tt.wantPhase, got)
+			}
+
+			if got := m.LostDate(); !got.Equal(tt.wantLost) {
+				t.Errorf("want lost date %v, got %v", tt.wantLost, got)
+			}
+
+			if got := m.ServiceState().Label; got != tt.wantState {
+				t.Errorf("want %s state, got %s", tt.wantState, got)
+			}
+
+			if got := m.OneLineCheckSummary(); !strings.Contains(got, tt.wantSummary) {
+				t.Errorf("\nwant summary containing %q\ngot %q", tt.wantSummary, got)
+			}
+		})
+	}
+}
+
+// TestGracePeriodTable asserts that grace period lengths are parsed and
+// selected using the longest matching public suffix.
+func TestGracePeriodTable(t *testing.T) {
+	t.Parallel()
+
+	uk, err := ParseGracePeriods("90:0:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	couk, err := ParseGracePeriods(" 30 : 0 : 5 ")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	table := GracePeriodTable{"uk": uk, "co.uk": couk}
+
+	tests := map[string]struct {
+		table GracePeriodTable
+		name  string
+		want  GracePeriods
+	}{
+		"longest suffix": {table: table, name: "example.co.uk", want: couk},
+		"shorter suffix": {table: table, name: "example.org.uk", want: uk},
+		"not listed":     {table: table, name: "example.com", want: DefaultGracePeriods},
+		"nil table":      {table: nil, name: "example.com", want: DefaultGracePeriods},
+		"default entry": {
+			table: GracePeriodTable{"*": uk},
+			name:  "example.com",
+			want:  uk,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tt.table.For(tt.name); got != tt.want {
+				t.Errorf("want %s, got %s", tt.want, got)
+			}
+		})
+	}
+
+	for _, invalid := range []string{"", "45:30", "45:30:x", "45:-1:5"} {
+		if _, err := ParseGracePeriods(invalid); !errors.Is(err, ErrInvalidGracePeriods) {
+			t.Errorf("want error for %q, got %v", invalid, err)
+		}
+	}
+}
@@ -0,0 +1,233 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-whois
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/atc0005/go-nagios"
+)
+
+// ErrRegistryLockMissing is returned whenever a specified domain requires a
+// registry lock but one or more of the registry (server) lock statuses are
+// not set.
+var ErrRegistryLockMissing = errors.New("registry lock statuses missing")
+
+// ErrRegistrarLockMissing is returned whenever a specified domain requires a
+// registrar lock but one or more of the registrar (client) lock statuses are
+// not set.
+var ErrRegistrarLockMissing = errors.New("registrar lock statuses missing")
+
+// EPP status codes used to lock a domain at the registry (server) level.
+const (
+	StatusServerTransferProhibited string = "serverTransferProhibited"
+	StatusServerUpdateProhibited   string = "serverUpdateProhibited"
+	StatusServerDeleteProhibited   string = "serverDeleteProhibited"
+)
+
+// EPP status codes used to lock a domain at the registrar (client) level.
+const (
+	StatusClientTransferProhibited string = "clientTransferProhibited"
+	StatusClientUpdateProhibited   string = "clientUpdateProhibited"
+	StatusClientDeleteProhibited   string = "clientDeleteProhibited"
+)
+
+// RegistryLockStatuses provides the collection of EPP status codes which
+// together make up a registry lock.
+func RegistryLockStatuses() []string {
+	return []string{
+		StatusServerTransferProhibited,
+		StatusServerUpdateProhibited,
+		StatusServerDeleteProhibited,
+	}
+}
+
+// RegistrarLockStatuses provides the collection of EPP status codes which
+// together make up a registrar lock.
+func RegistrarLockStatuses() []string {
+	return []string{
+		StatusClientTransferProhibited,
+		StatusClientUpdateProhibited,
+		StatusClientDeleteProhibited,
+	}
+}
+
+// StatusCode provides the EPP status code from the given domain status
+// value, removing any URL registries append to (or use in place of) the
+// code. For example, "clientTransferProhibited
+// https://icann.org/epp#clientTransferProhibited",
+// "clientTransferProhibited(https://icann.org/epp#clientTransferProhibited)"
+// and "https://icann.org/epp#clientTransferProhibited" each provide
+// "clientTransferProhibited".
+func StatusCode(value string) string {
+	value = strings.TrimSpace(value)
+
+	// A URL alone uses the status code as the fragment.
+	if strings.Contains(value, "://") && !strings.ContainsAny(value, " \t(") {
+		if _, fragment, found := strings.Cut(value, "#"); found {
+			return strings.TrimSpace(fragment)
+		}
+	}
+
+	if index := strings.IndexAny(value, " \t("); index != -1 {
+		value = value[:index]
+	}
+
+	return value
+}
+
+// HasStatus indicates whether the given EPP status code is set for the
+// domain. Status codes are compared case-insensitively after removing any
+// URL suffix.
+func (m Metadata) HasStatus(code string) bool {
+	if m.WhoisInfo.Domain == nil {
+		return false
+	}
+
+	for _, status := range m.WhoisInfo.Domain.Status {
+		if strings.EqualFold(StatusCode(status), code) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// missingStatuses provides the given EPP status codes which are not set for
+// the domain.
+func (m Metadata) missingStatuses(codes []string) []string {
+	var missing []string
+	for _, code := range codes {
+		if !m.HasStatus(code) {
+			missing = append(missing, code)
+		}
+	}
+
+	return missing
+}
+
+// MissingRegistryLockStatuses provides the registry lock status codes which
+// are not set for the domain.
+func (m Metadata) MissingRegistryLockStatuses() []string {
+	return m.missingStatuses(RegistryLockStatuses())
+}
+
+// MissingRegistrarLockStatuses provides the registrar lock status codes
+// which are not set for the domain.
+func (m Metadata) MissingRegistrarLockStatuses() []string {
+	return m.missingStatuses(RegistrarLockStatuses())
+}
+
+// IsRegistryLockMissing indicates whether a registry lock is required but
+// one or more of the registry lock status codes are not set. This returns
+// false if a registry lock is not required.
+func (m Metadata) IsRegistryLockMissing() bool {
+	return m.RequireRegistryLock && len(m.MissingRegistryLockStatuses()) > 0
+}
+
+// IsRegistrarLockMissing indicates whether a registrar lock is required but
+// one or more of the registrar lock status codes are not set. This returns
+// false if a registrar lock is not required.
+func (m Metadata) IsRegistrarLockMissing() bool {
+	return m.RequireRegistrarLock && len(m.MissingRegistrarLockStatuses()) > 0
+}
+
+// IsLockMissing indicates whether any required registry or registrar lock
+// status codes are not set.
+func (m Metadata) IsLockMissing() bool {
+	return m.IsRegistryLockMissing() || m.IsRegistrarLockMissing()
+}
+
+// LockCoverage provides the percentage (0 to 100) of registry and registrar
+// lock status codes set for the domain.
+func (m Metadata) LockCoverage() int {
+	codes := append(RegistryLockStatuses(), RegistrarLockStatuses()...)
+	missing := m.missingStatuses(codes)
+
+	return (len(codes) - len(missing)) * 100 / len(codes)
+}
+
+// RegistryLockState describes whether the registry (server) lock status
+// codes set for the domain are complete, partial or none.
+func (m Metadata) RegistryLockState() string {
+	return m.lockState(RegistryLockStatuses())
+}
+
+// RegistrarLockState describes whether the registrar (client) lock status
+// codes set for the domain are complete, partial or none.
+func (m Metadata) RegistrarLockState() string {
+	return m.lockState(RegistrarLockStatuses())
+}
+
+// lockState describes whether the given lock status codes set for the
+// domain are complete, partial or none.
+func (m Metadata) lockState(codes []string) string {
+	switch len(m.missingStatuses(codes)) {
+	case 0:
+		return "complete"
+	case len(codes):
+		return "none"
+	default:
+		return "partial"
+	}
+}
+
+// lockSummary provides a description of the given lock statuses for
+// inclusion in the one-line summary (e.g., "registry lock missing
+// serverUpdateProhibited").
+func lockSummary(kind string, missing []string) string {
+	return fmt.Sprintf("%s lock missing %s", kind, strings.Join(missing, ", "))
+}
+
+// writeLocks writes the registry and registrar lock statuses for the domain
+// to the given report.
+func (m Metadata) writeLocks(report *strings.Builder) {
+	_, _ = fmt.Fprintf(
+		report,
+		"%sLocks:%s%s",
+		nagios.CheckOutputEOL,
+		nagios.CheckOutputEOL,
+		nagios.CheckOutputEOL,
+	)
+
+	writeLock := func(heading string, codes []string, required bool) {
+		coverage := m.lockState(codes)
+
+		if required {
+			coverage += " (required)"
+		}
+
+		_, _ = fmt.Fprintf(
+			report,
+			"* %s: %s%s",
+			heading,
+			coverage,
+			nagios.CheckOutputEOL,
+		)
+
+		for _, code := range codes {
+			state := "set"
+			if !m.HasStatus(code) {
+				state = "not set"
+			}
+
+			_, _ = fmt.Fprintf(
+				report,
+				"  * %s: %s%s",
+				code,
+				state,
+				nagios.CheckOutputEOL,
+			)
+		}
+	}
+
+	writeLock("Registry Lock", RegistryLockStatuses(), m.RequireRegistryLock)
+	writeLock("Registrar Lock", RegistrarLockStatuses(), m.RequireRegistrarLock)
+}
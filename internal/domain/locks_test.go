@@ -0,0 +1,169 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-whois
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package domain
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/atc0005/go-nagios"
+	whoisparser "github.com/likexian/whois-parser"
+)
+
+// TestStatusCode asserts that URL suffixes (or URLs used in place of the
+// status code) are removed from domain status values.
+func TestStatusCode(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		value string
+		want  string
+	}{
+		"code":            {value: "clientTransferProhibited", want: "clientTransferProhibited"},
+		"padded code":     {value: "  ok  ", want: "ok"},
+		"url suffix":      {value: "clientTransferProhibited https://icann.org/epp#clientTransferProhibited", want: "clientTransferProhibited"},
+		"bracketed url":   {value: "serverDeleteProhibited (https://www.icann.org/epp#serverDeleteProhibited)", want: "serverDeleteProhibited"},
+		"attached url":    {value: "serverUpdateProhibited(https://icann.org/epp#serverUpdateProhibited)", want: "serverUpdateProhibited"},
+		"url only":        {value: "https://icann.org/epp#clientDeleteProhibited", want: "clientDeleteProhibited"},
+		"url without tag": {value: "https://icann.org/epp", want: "https://icann.org/epp"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := StatusCode(tt.value); got != tt.want {
+				t.Errorf("want %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+// TestLocks asserts that registry and registrar lock statuses are evaluated
+// against the required locks and reflected in the service state, lock
+// coverage and report.
+func TestLocks(t *testing.T) {
+	t.Parallel()
+
+	criticalState := nagios.ServiceState{
+		Label:    nagios.StateCRITICALLabel,
+		ExitCode: nagios.StateCRITICALExitCode,
+	}
+
+	tests := map[string]struct {
+		statuses         []string
+		requireRegistry  bool
+		requireRegistrar bool
+		wantMissing      []string
+		wantCoverage     int
+		wantState        string
+		wantReport       []string
+	}{
+		"no locks not required": {
+			statuses:     []string{"ok"},
+			wantCoverage: 0,
+			wantState:    nagios.StateOKLabel,
+			wantReport: []string{
+				"* Registry Lock: none",
+				"  * serverTransferProhibited: not set",
+			},
+		},
+		"registrar lock required and set": {
+			statuses: []string{
+				"clientTransferProhibited https://icann.org/epp#clientTransferProhibited",
+				"CLIENTUPDATEPROHIBITED",
+				"https://icann.org/epp#clientDeleteProhibited",
+			},
+			requireRegistrar: true,
+			wantCoverage:     50,
+			wantState:        nagios.StateOKLabel,
+			wantReport: []string{
+				"* Registrar Lock: complete (required)",
+				"  * clientUpdateProhibited: set",
+			},
+		},
+		"registry lock required and partially set": {
+			statuses: []string{
+				"serverTransferProhibited https://icann.org/epp#serverTransferProhibited",
+				"clientTransferProhibited https://icann.org/epp#clientTransferProhibited",
+			},
+			requireRegistry: true,
+			wantMissing: []string{
+				StatusServerUpdateProhibited,
+				StatusServerDeleteProhibited,
+			},
+			wantCoverage: 33,
+			wantState:    nagios.StateCRITICALLabel,
+			wantReport: []string{
+				"* Registry Lock: partial (required)",
+				"  * serverTransferProhibited: set",
+				"  * serverDeleteProhibited: not set",
+			},
+		},
+		"all locks": {
+			statuses: append(
+				RegistryLockStatuses(),
+				RegistrarLockStatuses()...,
+			),
+			requireRegistry:  true,
+			requireRegistrar: true,
+			wantCoverage:     100,
+			wantState:        nagios.StateOKLabel,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			warning, critical := testThresholds(t)
+
+			info := whoisparser.WhoisInfo{
+				Domain: &whoisparser.Domain{
+					Domain:         "example.com",
+					ExpirationDate: "2027-08-12",
+					Status:         tt.statuses,
+				},
+			}
+
+			m, err := NewDomain(info, warning, critical)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			m.RequireRegistryLock = tt.requireRegistry
+			m.RequireRegistrarLock = tt.requireRegistrar
+			m.MissingLockState = criticalState
+			m.Clock = FixedClock(goldenNow)
+
+			if got := m.MissingRegistryLockStatuses(); tt.requireRegistry && !slices.Equal(got, tt.wantMissing) {
+				t.Errorf("want missing registry lock statuses %v, got %v", tt.wantMissing, got)
+			}
+
+			if got := m.IsLockMissing(); got != (len(tt.wantMissing) > 0) {
+				t.Errorf("want lock missing %t, got %t", len(tt.wantMissing) > 0, got)
+			}
+
+			if got := m.LockCoverage(); got != tt.wantCoverage {
+				t.Errorf("want lock coverage %d, got %d", tt.wantCoverage, got)
+			}
+
+			if got := m.ServiceState().Label; got != tt.wantState {
+				t.Errorf("want %s state, got %s", tt.wantState, got)
+			}
+
+			report := m.Report()
+			for _, want := range tt.wantReport {
+				if !strings.Contains(report, want) {
+					t.Errorf("\nwant report containing %q\ngot:\n%s", want, report)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-whois
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+
+	"golang.org/x/net/publicsuffix"
+)
+
+// ErrNotRegistrableDomain indicates that a given name is not a registrable
+// domain (e.g., the name is a public suffix such as "co.uk" or is a
+// subdomain of a registrable domain).
+var ErrNotRegistrableDomain = errors.New("not a registrable domain")
+
+// HostFromInput extracts the host portion of user-provided input which may
+// be given as a bare domain name or as a URL. Any scheme, user info, port,
+// path, query string or fragment is removed. The input is returned (minus
+// surrounding whitespace) if no host portion is found.
+func HostFromInput(input string) string {
+	host := strings.TrimSpace(input)
+
+	if i := strings.Index(host, "://"); i >= 0 {
+		host = host[i+len("://"):]
+	}
+
+	if i := strings.IndexAny(host, "/?#"); i >= 0 {
+		host = host[:i]
+	}
+
+	if i := strings.LastIndex(host, "@"); i >= 0 {
+		host = host[i+1:]
+	}
+
+	if hostname, _, err := net.SplitHostPort(host); err == nil {
+		host = hostname
+	}
+
+	return host
+}
+
+// RegistrableDomain uses the embedded Public Suffix List to reduce the given
+// ASCII domain name to the registrable domain (e.g., "www.example.co.uk"
+// becomes "example.co.uk"). Only the ICANN section of the list is used;
+// private suffixes (e.g., "github.io") are registered with the registry as
+// ordinary domains and are not considered when determining the registrable
+// domain.
+//
+// An error is returned if the name is itself a public suffix.
+func RegistrableDomain(name string) (string, error) {
+	name = strings.ToLower(strings.TrimSuffix(name, "."))
+
+	suffix, icann := publicsuffix.PublicSuffix(name)
+
+	// Walk back from private suffixes to the ICANN suffix they belong to.
+	for !icann {
+		i := strings.IndexByte(suffix, '.')
+		if i < 0 {
+			break
+		}
+		suffix, icann = publicsuffix.PublicSuffix(suffix[i+1:])
+	}
+
+	if name == suffix || !strings.HasSuffix(name, "."+suffix) {
+		return "", fmt.Errorf(
+			"%q is a public suffix: %w",
+			name,
+			ErrNotRegistrableDomain,
+		)
+	}
+
+	labels := strings.Split(strings.TrimSuffix(name, "."+suffix), ".")
+
+	return labels[len(labels)-1] + "." + suffix, nil
+}
@@ -0,0 +1,260 @@
+// Copyright 2021 Adam Chalkley
+//
+// https://github.com/atc0005/check-whois
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/atc0005/go-nagios"
+)
+
+// ErrUnsupportedMetric indicates that an unsupported performance data
+// metric was requested.
+var ErrUnsupportedMetric = errors.New("unsupported performance data metric")
+
+// Metric is the label of a performance data metric.
+type Metric string
+
+// Supported performance data metrics.
+const (
+
+	// MetricExpires is the number of days remaining until expiration.
+	MetricExpires Metric = "expires"
+
+	// MetricExpiresSeconds is the number of seconds remaining until
+	// expiration.
+	MetricExpiresSeconds Metric = "expires_seconds"
+
+	// MetricSinceUpdate is the number of days elapsed since the domain was
+	// last updated.
+	MetricSinceUpdate Metric = "since_update"
+
+	// MetricSinceCreation is the number of days elapsed since the domain was
+	// created.
+	MetricSinceCreation Metric = "since_creation"
+
+	// MetricLockCoverage is the percentage of registry and registrar lock
+	// status codes set.
+	MetricLockCoverage Metric = "lock_coverage"
+
+	// MetricLookupTime is the time taken to retrieve the registration data
+	// (e.g., the WHOIS round trip including referral queries).
+	MetricLookupTime Metric = "lookup_time"
+
+	// MetricReferralHops is the number of referral queries made to retrieve
+	// the registration data.
+	MetricReferralHops Metric = "referral_hops"
+
+	// MetricResponseBytes is the size of the registration data response.
+	MetricResponseBytes Metric = "response_bytes"
+
+	// MetricNameservers is the number of nameservers listed for the domain.
+	MetricNameservers Metric = "nameservers"
+
+	// MetricStatusCodes is the number of domain status codes set.
+	MetricStatusCodes Metric = "status_codes"
+
+	// MetricDNSSEC indicates whether the domain is DNSSEC signed (1) or not
+	// (0).
+	MetricDNSSEC Metric = "dnssec"
+)
+
+// SupportedMetrics provides the collection of supported performance data
+// metrics.
+func SupportedMetrics() []Metric {
+	return []Metric{
+		MetricExpires,
+		MetricExpiresSeconds,
+		MetricSinceUpdate,
+		MetricSinceCreation,
+		MetricLockCoverage,
+		MetricLookupTime,
+		MetricReferralHops,
+		MetricResponseBytes,
+		MetricNameservers,
+		MetricStatusCodes,
+		MetricDNSSEC,
+	}
+}
+
+// ParseMetric asserts that the given value is a supported performance data
+// metric.
+func ParseMetric(value string) (Metric, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+
+	for _, metric := range SupportedMetrics() {
+		if value == string(metric) {
+			return metric, nil
+		}
+	}
+
+	return "", fmt.Errorf("%w: %q", ErrUnsupportedMetric, value)
+}
+
+// LookupMetrics describes the retrieval of the registration data evaluated
+// for a domain.
+type LookupMetrics struct {
+
+	// Duration is the time taken to retrieve the registration data.
+	Duration time.Duration
+
+	// ReferralHops is the number of referral queries made to retrieve the
+	// registration data.
+	ReferralHops int
+
+	// ResponseBytes is the size of the registration data.
+	ResponseBytes int
+}
+
+// PerfData generates performance data metrics from the given domain
+// metadata. An error is returned if any are encountered while gathering
+// metrics or if invalid domain metadata is provided.
+//
+// Only the metrics listed by the domain metadata are generated, or all
+// metrics if none are listed. Lookup metrics are omitted if the retrieval of
+// the registration data was not recorded.
+func PerfData(d *Metadata) ([]nagios.PerformanceData, error) {
+
+	if d == nil {
+		return nil, fmt.Errorf(
+			"func PerfData: unable to generate performance data: %w",
+			ErrMissingValue,
+		)
+	}
+
+	expires := nagios.PerformanceData{
+		// Use the same value and Nagios ranges evaluated when determining
+		// the service state so that the two always agree.
+		Label:             string(MetricExpires),
+		Value:             d.ExpirationValue(),
+		UnitOfMeasurement: "d",
+		Warn:              d.AgeWarningThreshold.Range(),
+		Crit:              d.AgeCriticalThreshold.Range(),
+	}
+
+	// Expiration thresholds are not evaluated for auto-renewed domains.
+	if d.IsAutoRenewed() {
+		expires.Warn = ""
+		expires.Crit = ""
+	}
+
+	pd := []nagios.PerformanceData{
+		expires,
+		{
+			// Thresholds are evaluated using days; the expires metric
+			// provides them.
+			Label:             string(MetricExpiresSeconds),
+			Value:             strconv.FormatInt(int64(d.ExpirationDate.Sub(d.Now())/time.Second), 10),
+			UnitOfMeasurement: "s",
+		},
+	}
+
+	// The updated and created dates are optional; metrics are omitted for
+	// dates not specified by the registry.
+	if d.HasUpdatedDate() {
+		pd = append(pd, nagios.PerformanceData{
+			Label:             string(MetricSinceUpdate),
+			Value:             d.UpdatedValue(),
+			UnitOfMeasurement: "d",
+			Warn:              d.UpdatedWarningThreshold.Range(),
+			Crit:              d.UpdatedCriticalThreshold.Range(),
+		})
+	}
+
+	if d.HasCreatedDate() {
+		pd = append(pd, nagios.PerformanceData{
+			Label:             string(MetricSinceCreation),
+			Value:             d.CreatedValue(),
+			UnitOfMeasurement: "d",
+			Warn:              d.CreatedWarningThreshold.Range(),
+			Crit:              d.CreatedCriticalThreshold.Range(),
+		})
+	}
+
+	// Percentage of registry and registrar lock status codes set.
+	pd = append(pd, nagios.PerformanceData{
+		Label:             string(MetricLockCoverage),
+		Value:             strconv.Itoa(d.LockCoverage()),
+		UnitOfMeasurement: "%",
+		Min:               "0",
+		Max:               "100",
+	})
+
+	if d.Lookup != nil {
+		pd = append(pd,
+			nagios.PerformanceData{
+				Label:             string(MetricLookupTime),
+				Value:             strconv.FormatInt(d.Lookup.Duration.Milliseconds(), 10),
+				UnitOfMeasurement: "ms",
+				Min:               "0",
+			},
+			nagios.PerformanceData{
+				Label: string(MetricReferralHops),
+				Value: strconv.Itoa(d.Lookup.ReferralHops),
+				Min:   "0",
+			},
+			nagios.PerformanceData{
+				Label:             string(MetricResponseBytes),
+				Value:             strconv.Itoa(d.Lookup.ResponseBytes),
+				UnitOfMeasurement: "B",
+				Min:               "0",
+			},
+		)
+	}
+
+	var nameservers, statuses int
+	var dnssec bool
+	if d.WhoisInfo.Domain != nil {
+		nameservers = len(d.WhoisInfo.Domain.NameServers)
+		statuses = len(d.WhoisInfo.Domain.Status)
+		dnssec = d.WhoisInfo.Domain.DNSSec
+	}
+
+	dnssecValue := "0"
+	if dnssec {
+		dnssecValue = "1"
+	}
+
+	pd = append(pd,
+		nagios.PerformanceData{
+			Label: string(MetricNameservers),
+			Value: strconv.Itoa(nameservers),
+			Min:   "0",
+		},
+		nagios.PerformanceData{
+			Label: string(MetricStatusCodes),
+			Value: strconv.Itoa(statuses),
+			Min:   "0",
+		},
+		nagios.PerformanceData{
+			Label: string(MetricDNSSEC),
+			Value: dnssecValue,
+			Min:   "0",
+			Max:   "1",
+		},
+	)
+
+	if len(d.Metrics) == 0 {
+		return pd, nil
+	}
+
+	selected := make([]nagios.PerformanceData, 0, len(d.Metrics))
+	for _, metric := range pd {
+		if slices.Contains(d.Metrics, Metric(metric.Label)) {
+			selected = append(selected, metric)
+		}
+	}
+
+	return selected, nil
+
+}
@@ -0,0 +1,139 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-whois
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package domain
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	whoisparser "github.com/likexian/whois-parser"
+)
+
+// TestPerfDataMetrics asserts that lookup metrics are generated when the
+// retrieval of registration data is recorded and that only the selected
+// metrics are generated.
+func TestPerfDataMetrics(t *testing.T) {
+	t.Parallel()
+
+	warning, critical := testThresholds(t)
+
+	lookup := &LookupMetrics{
+		Duration:      1500 * time.Millisecond,
+		ReferralHops:  1,
+		ResponseBytes: 2048,
+	}
+
+	tests := map[string]struct {
+		lookup  *LookupMetrics
+		metrics []Metric
+		want    map[string]string
+	}{
+		"lookup not recorded": {
+			want: map[string]string{
+				"expires":         "20.25",
+				"expires_seconds": "1749600",
+				"lock_coverage":   "16",
+				"nameservers":     "2",
+				"status_codes":    "1",
+				"dnssec":          "1",
+			},
+		},
+		"lookup recorded": {
+			lookup: lookup,
+			want: map[string]string{
+				"expires":         "20.25",
+				"expires_seconds": "1749600",
+				"lock_coverage":   "16",
+				"lookup_time":     "1500",
+				"referral_hops":   "1",
+				"response_bytes":  "2048",
+				"nameservers":     "2",
+				"status_codes":    "1",
+				"dnssec":          "1",
+			},
+		},
+		"selected metrics": {
+			lookup:  lookup,
+			metrics: []Metric{MetricLookupTime, MetricExpires, MetricSinceUpdate},
+			want: map[string]string{
+				"expires":     "20.25",
+				"lookup_time": "1500",
+			},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			info := whoisparser.WhoisInfo{
+				Domain: &whoisparser.Domain{
+					Domain:         "example.com",
+					ExpirationDate: "2026-02-04T06:00:00Z",
+					Status:         []string{"clientTransferProhibited"},
+					NameServers:    []string{"ns1.example.com", "ns2.example.com"},
+					DNSSec:         true,
+				},
+			}
+
+			m, err := NewDomain(info, warning, critical)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			m.Clock = FixedClock(goldenNow)
+			m.Lookup = tt.lookup
+			m.Metrics = tt.metrics
+
+			pd, err := PerfData(m)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := make(map[string]string, len(pd))
+			for _, metric := range pd {
+				if err := metric.Validate(); err != nil {
+					t.Errorf("invalid performance data %q: %v", metric.Label, err)
+				}
+
+				got[metric.Label] = metric.Value
+			}
+
+			if len(got) != len(tt.want) {
+				t.Errorf("want metrics %v, got %v", tt.want, got)
+			}
+
+			for label, value := range tt.want {
+				if got[label] != value {
+					t.Errorf("want %s=%s, got %q", label, value, got[label])
+				}
+			}
+		})
+	}
+}
+
+// TestParseMetric asserts that supported metrics are accepted regardless of
+// case and that unsupported metrics are rejected.
+func TestParseMetric(t *testing.T) {
+	t.Parallel()
+
+	metric, err := ParseMetric(" Lookup_Time ")
+	if err != nil || metric != MetricLookupTime {
+		t.Errorf("want %s, got %q (%v)", MetricLookupTime, metric, err)
+	}
+
+	if _, err := ParseMetric("time"); !errors.Is(err, ErrUnsupportedMetric) {
+		t.Errorf("want ErrUnsupportedMetric, got %v", err)
+	}
+
+	if !slices.Contains(SupportedMetrics(), MetricDNSSEC) {
+		t.Error("want dnssec listed as a supported metric")
+	}
+}
@@ -0,0 +1,141 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-whois
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrUnsupportedPrivacyMode indicates that an unsupported registrant privacy
+// mode was specified.
+var ErrUnsupportedPrivacyMode = errors.New("unsupported privacy mode")
+
+// ErrPrivacyMismatch is returned whenever the registrant privacy mode for a
+// specified domain does not match the expected privacy mode.
+var ErrPrivacyMismatch = errors.New("registrant privacy mode does not match expected mode")
+
+// PrivacyMode identifies how registrant details are published in the
+// registration data for a domain.
+type PrivacyMode string
+
+// Supported registrant privacy modes.
+const (
+
+	// PrivacyModeRedacted indicates that registrant details are withheld or
+	// redacted by the registry or registrar (e.g., for GDPR compliance).
+	PrivacyModeRedacted PrivacyMode = "redacted"
+
+	// PrivacyModeProxy indicates that registrant details are replaced by
+	// those of a privacy or proxy service (e.g., "Domains By Proxy, LLC").
+	PrivacyModeProxy PrivacyMode = "proxy"
+
+	// PrivacyModePublic indicates that the registrant name, organization or
+	// email is publicly listed.
+	PrivacyModePublic PrivacyMode = "public"
+)
+
+// privacyProxyServices is the list of (lowercase) names of known privacy or
+// proxy services used in place of registrant details. A value containing any
+// of these names is treated as a privacy proxy.
+var privacyProxyServices = []string{
+	"withheld for privacy",
+	"domains by proxy",
+	"whoisguard",
+	"contact privacy inc",
+	"privacyguardian.org",
+	"perfect privacy, llc",
+	"whois privacy protection service",
+	"whoisprivacyprotect",
+	"domain protection services",
+	"identity protection service",
+	"super privacy service",
+	"proxy protection llc",
+	"private by design, llc",
+	"registration private",
+	"privacy protect, llc",
+	"privacyprotect.org",
+	"whoisproxy",
+	"domain privacy service",
+}
+
+// SupportedPrivacyModes provides the collection of supported registrant
+// privacy modes.
+func SupportedPrivacyModes() []PrivacyMode {
+	return []PrivacyMode{
+		PrivacyModeRedacted,
+		PrivacyModeProxy,
+		PrivacyModePublic,
+	}
+}
+
+// ParsePrivacyMode asserts that the given value is a supported registrant
+// privacy mode.
+func ParsePrivacyMode(value string) (PrivacyMode, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+
+	for _, mode := range SupportedPrivacyModes() {
+		if value == string(mode) {
+			return mode, nil
+		}
+	}
+
+	return "", fmt.Errorf("%w: %q", ErrUnsupportedPrivacyMode, value)
+}
+
+// IsPrivacyProxy indicates whether the given WHOIS record value names a
+// known privacy or proxy service rather than the actual registrant.
+func IsPrivacyProxy(value string) bool {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if value == "" {
+		return false
+	}
+
+	for _, service := range privacyProxyServices {
+		if strings.Contains(value, service) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// RegistrantPrivacy provides the classification of the registrant details
+// from the WhoIS record as redacted, privacy proxied or public.
+func (m Metadata) RegistrantPrivacy() PrivacyMode {
+	return registrantPrivacy(m)
+}
+
+// IsPrivacyMismatch indicates whether the registrant privacy mode does not
+// match any of the optional expected privacy modes. This returns false if no
+// expected privacy modes are specified.
+func (m Metadata) IsPrivacyMismatch() bool {
+	if len(m.ExpectedPrivacy) == 0 {
+		return false
+	}
+
+	privacy := registrantPrivacy(m)
+	for _, expected := range m.ExpectedPrivacy {
+		if privacy == expected {
+			return false
+		}
+	}
+
+	return true
+}
+
+// expectedPrivacy provides the optional expected privacy modes for display.
+func (m Metadata) expectedPrivacy() string {
+	modes := make([]string, 0, len(m.ExpectedPrivacy))
+	for _, mode := range m.ExpectedPrivacy {
+		modes = append(modes, string(mode))
+	}
+
+	return strings.Join(modes, " or ")
+}
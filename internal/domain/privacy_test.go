@@ -0,0 +1,204 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-whois
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package domain
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/atc0005/go-nagios"
+	whoisparser "github.com/likexian/whois-parser"
+)
+
+// TestRegistrantPrivacy asserts that registrant details are classified as
+// redacted, privacy proxied or public.
+func TestRegistrantPrivacy(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		registrant *whoisparser.Contact
+		want       PrivacyMode
+	}{
+		"missing registrant": {
+			registrant: nil,
+			want:       PrivacyModeRedacted,
+		},
+		"empty registrant": {
+			registrant: &whoisparser.Contact{Country: "GB"},
+			want:       PrivacyModeRedacted,
+		},
+		"redacted registrant": {
+			registrant: &whoisparser.Contact{
+				Name:         "REDACTED FOR PRIVACY",
+				Organization: "REDACTED FOR PRIVACY",
+				Email:        "Please query the RDDS service of the Registrar of Record",
+			},
+			want: PrivacyModeRedacted,
+		},
+		"privacy proxy organization": {
+			registrant: &whoisparser.Contact{
+				Name:         "REDACTED FOR PRIVACY",
+				Organization: "Privacy service provided by Withheld for Privacy ehf",
+			},
+			want: PrivacyModeProxy,
+		},
+		"privacy proxy name": {
+			registrant: &whoisparser.Contact{
+				Name:  "Registration Private",
+				Email: "example.com@domainsbyproxy.com",
+			},
+			want: PrivacyModeProxy,
+		},
+		"public organization with redacted name": {
+			registrant: &whoisparser.Contact{
+				Name:         "REDACTED FOR PRIVACY",
+				Organization: "Example Company, Inc.",
+			},
+			want: PrivacyModePublic,
+		},
+		"public registrant": {
+			registrant: &whoisparser.Contact{
+				Name:  "Domain Administrator",
+				Email: "hostmaster@example.com",
+			},
+			want: PrivacyModePublic,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			m := Metadata{
+				WhoisInfo: whoisparser.WhoisInfo{Registrant: tt.registrant},
+			}
+
+			if got := m.RegistrantPrivacy(); got != tt.want {
+				t.Errorf("want %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+// TestParsePrivacyMode asserts that supported privacy modes are accepted
+// regardless of case and that unsupported modes are rejected.
+func TestParsePrivacyMode(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		value   string
+		want    PrivacyMode
+		wantErr error
+	}{
+		"redacted":    {value: "redacted", want: PrivacyModeRedacted},
+		"proxy":       {value: " Proxy ", want: PrivacyModeProxy},
+		"public":      {value: "PUBLIC", want: PrivacyModePublic},
+		"unsupported": {value: "private", wantErr: ErrUnsupportedPrivacyMode},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ParsePrivacyMode(tt.value)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("want error %v, got %v", tt.wantErr, err)
+			}
+
+			if got != tt.want {
+				t.Errorf("want %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+// TestPrivacyMismatchServiceState asserts that the privacy mismatch state is
+// used when the registrant privacy mode does not match the expected modes
+// and is more severe than the state for the evaluated thresholds.
+func TestPrivacyMismatchServiceState(t *testing.T) {
+	t.Parallel()
+
+	warningState := nagios.ServiceState{
+		Label:    nagios.StateWARNINGLabel,
+		ExitCode: nagios.StateWARNINGExitCode,
+	}
+
+	criticalState := nagios.ServiceState{
+		Label:    nagios.StateCRITICALLabel,
+		ExitCode: nagios.StateCRITICALExitCode,
+	}
+
+	tests := map[string]struct {
+		expiration    string
+		expected      []PrivacyMode
+		mismatchState nagios.ServiceState
+		wantMismatch  bool
+		wantState     string
+	}{
+		"not evaluated": {
+			expiration:    "2027-08-12",
+			mismatchState: criticalState,
+			wantState:     nagios.StateOKLabel,
+		},
+		"match": {
+			expiration:    "2027-08-12",
+			expected:      []PrivacyMode{PrivacyModeRedacted, PrivacyModeProxy},
+			mismatchState: criticalState,
+			wantState:     nagios.StateOKLabel,
+		},
+		"mismatch": {
+			expiration:    "2027-08-12",
+			expected:      []PrivacyMode{PrivacyModePublic},
+			mismatchState: warningState,
+			wantMismatch:  true,
+			wantState:     nagios.StateWARNINGLabel,
+		},
+		"mismatch less severe than expiration": {
+			expiration:    "2026-01-20",
+			expected:      []PrivacyMode{PrivacyModePublic},
+			mismatchState: warningState,
+			wantMismatch:  true,
+			wantState:     nagios.StateCRITICALLabel,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			warning, critical := testThresholds(t)
+
+			info := whoisparser.WhoisInfo{
+				Domain: &whoisparser.Domain{
+					Domain:         "example.com",
+					ExpirationDate: tt.expiration,
+				},
+				Registrant: &whoisparser.Contact{
+					Organization: "Privacy service provided by Withheld for Privacy ehf",
+				},
+			}
+
+			m, err := NewDomain(info, warning, critical)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			m.ExpectedPrivacy = tt.expected
+			m.PrivacyMismatchState = tt.mismatchState
+			m.Clock = FixedClock(goldenNow)
+
+			if got := m.IsPrivacyMismatch(); got != tt.wantMismatch {
+				t.Errorf("want mismatch %t, got %t", tt.wantMismatch, got)
+			}
+
+			if got := m.ServiceState().Label; got != tt.wantState {
+				t.Errorf("want %s state, got %s", tt.wantState, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,241 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-whois
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package domain
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/atc0005/go-nagios"
+)
+
+// Templates is the collection of optional Go text/template templates used
+// to generate the one-line summary and report in place of the default
+// wording. Templates are provided a TemplateData value.
+type Templates struct {
+
+	// Summary is the optional template used to generate the one-line
+	// summary.
+	Summary *template.Template
+
+	// Report is the optional template used to generate the report.
+	Report *template.Template
+
+	// Vars is the collection of user-specified values (e.g., ticket
+	// numbers) available to the templates.
+	Vars map[string]string
+}
+
+// TemplateData is the data available to summary and report templates. The
+// fields and methods of the domain Metadata (e.g., .Name, .ExpirationDate,
+// .WhoisInfo, .Phase or .RegistrarName) are available along with values
+// computed when the template is executed.
+type TemplateData struct {
+	Metadata
+
+	// DisplayName is the quoted domain name along with the Unicode form of
+	// internationalized domain names (as used in the default wording).
+	DisplayName string
+
+	// State is the service state label (e.g., WARNING).
+	State string
+
+	// ExitCode is the plugin exit code for the service state.
+	ExitCode int
+
+	// DaysRemaining is the whole number of days remaining until expiration.
+	// The value is negative if the domain has expired.
+	DaysRemaining int
+
+	// HoursRemaining is the whole number of hours remaining until
+	// expiration in addition to DaysRemaining. The value is negative if the
+	// domain has expired.
+	HoursRemaining int
+
+	// Remaining describes the time remaining until (or since) expiration
+	// (e.g., "42d 10h remaining" or "3h ago").
+	Remaining string
+
+	// Statuses is the list of domain status codes.
+	Statuses []string
+
+	// FailedStates is the list of state labels for failed checks other than
+	// the expiration, updated and created date checks (e.g., privacy
+	// mismatch or missing locks).
+	FailedStates []string
+
+	// Vars is the collection of user-specified values (e.g., ticket
+	// numbers).
+	Vars map[string]string
+}
+
+// TemplateFuncs provides the helper functions available to summary and
+// report templates:
+//
+//   - date: formats a date using the display layout and time zone, or a
+//     placeholder if not specified (e.g., {{date .CreatedDate}})
+//   - formatDate: formats a date using the given layout and the display
+//     time zone, or a placeholder if not specified (e.g.,
+//     {{formatDate .ExpirationDate "2006-01-02"}})
+//   - relative: describes a date relative to the evaluation time (e.g.,
+//     {{relative .UpdatedDate}} provides "3d 2h ago")
+//   - join: joins a list of values (e.g., {{join .Statuses ", "}})
+//   - lower, upper: converts the case of a value
+//   - default: provides a fallback for an empty value (e.g.,
+//     {{default "none" (index .Vars .Name)}})
+func TemplateFuncs(now func() time.Time, format DateFormat) template.FuncMap {
+	return template.FuncMap{
+		"date": format.Format,
+		"formatDate": func(date time.Time, layout string) string {
+			return DateFormat{Layout: layout, Location: format.Location}.Format(date)
+		},
+		"relative": func(date time.Time) string {
+			if date.IsZero() {
+				return defaultWhoISPlaceholderValue
+			}
+
+			return FormattedExpiration(date, now())
+		},
+		"join":  strings.Join,
+		"lower": strings.ToLower,
+		"upper": strings.ToUpper,
+		"default": func(fallback string, value string) string {
+			if strings.TrimSpace(value) == "" {
+				return fallback
+			}
+
+			return value
+		},
+	}
+}
+
+// ParseTemplates parses the given summary and report templates. Empty
+// templates are not used; the default wording is used instead.
+func ParseTemplates(summary string, report string, vars map[string]string) (*Templates, error) {
+	templates := Templates{Vars: vars}
+
+	// Functions are bound to the evaluation time and date format when
+	// executed; these values are only used while parsing.
+	funcs := TemplateFuncs(time.Now, DateFormat{})
+
+	// References to unspecified variables (e.g., .Vars.ticket) evaluate to
+	// an empty value so that the default function may be used.
+	const option = "missingkey=zero"
+
+	if summary != "" {
+		tmpl, err := template.New("summary").Option(option).Funcs(funcs).Parse(summary)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse summary template: %w", err)
+		}
+
+		templates.Summary = tmpl
+	}
+
+	if report != "" {
+		tmpl, err := template.New("report").Option(option).Funcs(funcs).Parse(report)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse report template: %w", err)
+		}
+
+		templates.Report = tmpl
+	}
+
+	return &templates, nil
+}
+
+// TemplateData provides the data made available to summary and report
+// templates.
+func (m Metadata) TemplateData() TemplateData {
+	remaining := m.ExpirationDate.Sub(m.Now()).Hours()
+	days := math.Trunc(remaining / 24)
+
+	var vars map[string]string
+	if m.Templates != nil {
+		vars = m.Templates.Vars
+	}
+
+	var statuses []string
+	if m.WhoisInfo.Domain != nil {
+		statuses = m.WhoisInfo.Domain.Status
+	}
+
+	state := m.ServiceState()
+
+	return TemplateData{
+		Metadata:       m,
+		DisplayName:    m.displayName(),
+		State:          state.Label,
+		ExitCode:       state.ExitCode,
+		DaysRemaining:  int(days),
+		HoursRemaining: int(math.Trunc(remaining - days*24)),
+		Remaining:      FormattedExpiration(m.ExpirationDate, m.Now()),
+		Statuses:       statuses,
+		FailedStates:   m.failedCheckStates(),
+		Vars:           vars,
+	}
+}
+
+// executeTemplate applies the given template to the domain metadata. The
+// template functions are bound to the evaluation time and date format of the
+// metadata.
+func (m Metadata) executeTemplate(tmpl *template.Template) (string, error) {
+	// Clone so that binding functions to this evaluation does not affect
+	// other domains evaluated at the same time.
+	clone, err := tmpl.Clone()
+	if err != nil {
+		return "", err
+	}
+
+	var output strings.Builder
+	if err := clone.Funcs(TemplateFuncs(m.Now, m.DateFormat)).Execute(&output, m.TemplateData()); err != nil {
+		return "", err
+	}
+
+	return output.String(), nil
+}
+
+// templatedSummary provides the one-line summary generated using the summary
+// template. The default wording is used (noting the error) if the template
+// cannot be executed.
+func (m Metadata) templatedSummary() string {
+	summary, err := m.executeTemplate(m.Templates.Summary)
+	if err != nil {
+		return fmt.Sprintf(
+			"%s (summary template error: %v)%s",
+			strings.TrimSuffix(m.defaultSummary(), nagios.CheckOutputEOL),
+			err,
+			nagios.CheckOutputEOL,
+		)
+	}
+
+	// The summary is limited to a single line.
+	summary = strings.Join(strings.Fields(summary), " ")
+
+	return summary + nagios.CheckOutputEOL
+}
+
+// templatedReport provides the report generated using the report template.
+// The default report is used (noting the error) if the template cannot be
+// executed.
+func (m Metadata) templatedReport() string {
+	report, err := m.executeTemplate(m.Templates.Report)
+	if err != nil {
+		return fmt.Sprintf(
+			"Report template error: %v%s%s%s",
+			err,
+			nagios.CheckOutputEOL,
+			nagios.CheckOutputEOL,
+			m.defaultReport(),
+		)
+	}
+
+	return report
+}
@@ -0,0 +1,133 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-whois
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package domain
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/atc0005/go-nagios"
+	whoisparser "github.com/likexian/whois-parser"
+)
+
+// TestTemplates asserts that summary and report templates are provided the
+// domain metadata, computed values, user-specified variables and helper
+// functions, and that the default wording is used if a template cannot be
+// executed.
+func TestTemplates(t *testing.T) {
+	t.Parallel()
+
+	warning, critical := testThresholds(t)
+
+	tests := map[string]struct {
+		expiration  string
+		summary     string
+		report      string
+		vars        map[string]string
+		wantSummary string
+		wantReport  []string
+	}{
+		"summary with vars": {
+			expiration:  "2026-02-04T06:00:00Z",
+			summary:     "{{.State}}: {{.Name}} expires in {{.DaysRemaining}}d {{.HoursRemaining}}h; see {{.Vars.ticket}}",
+			vars:        map[string]string{"ticket": "INC0012345"},
+			wantSummary: "WARNING: example.com expires in 20d 6h; see INC0012345" + nagios.CheckOutputEOL,
+		},
+		"expired domain": {
+			expiration:  "2026-01-13T18:00:00Z",
+			summary:     "{{.DaysRemaining}}d {{.HoursRemaining}}h ({{relative .ExpirationDate}})",
+			wantSummary: "-1d -6h (1d 6h ago)" + nagios.CheckOutputEOL,
+		},
+		"multiline summary": {
+			expiration:  "2026-02-04T06:00:00Z",
+			summary:     "{{upper .Name}}\n  expires {{formatDate .ExpirationDate \"2006-01-02\"}}",
+			wantSummary: "EXAMPLE.COM expires 2026-02-04" + nagios.CheckOutputEOL,
+		},
+		"report helpers": {
+			expiration: "2026-02-04T06:00:00Z",
+			report:     "Statuses: {{join .Statuses \", \"}}\nCreated: {{date .CreatedDate}}\nOwner: {{default \"unassigned\" .Vars.owner}}",
+			wantReport: []string{
+				"Statuses: clientTransferProhibited, clientDeleteProhibited",
+				"Created: unspecified",
+				"Owner: unassigned",
+			},
+		},
+		"summary execution error": {
+			expiration:  "2026-02-04T06:00:00Z",
+			summary:     "{{.Missing}}",
+			wantSummary: `WARNING: "example.com" domain registration has 20d 6h remaining (summary template error: `,
+		},
+		"report execution error": {
+			expiration: "2026-02-04T06:00:00Z",
+			report:     "{{.Missing}}",
+			wantReport: []string{"Report template error: ", "WHOIS metadata for"},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			info := whoisparser.WhoisInfo{
+				Domain: &whoisparser.Domain{
+					Domain:         "example.com",
+					ExpirationDate: tt.expiration,
+					Status:         []string{"clientTransferProhibited", "clientDeleteProhibited"},
+				},
+			}
+
+			m, err := NewDomain(info, warning, critical)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			m.Clock = FixedClock(goldenNow)
+
+			m.Templates, err = ParseTemplates(tt.summary, tt.report, tt.vars)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if tt.wantSummary != "" {
+				if got := m.OneLineCheckSummary(); !strings.HasPrefix(got, tt.wantSummary) {
+					t.Errorf("\nwant summary %q\ngot %q", tt.wantSummary, got)
+				}
+			}
+
+			report := m.Report()
+			for _, want := range tt.wantReport {
+				if !strings.Contains(report, want) {
+					t.Errorf("\nwant report containing %q\ngot:\n%s", want, report)
+				}
+			}
+		})
+	}
+}
+
+// TestParseTemplates asserts that malformed templates are reported and that
+// empty templates are not used.
+func TestParseTemplates(t *testing.T) {
+	t.Parallel()
+
+	if _, err := ParseTemplates("{{.Name", "", nil); err == nil {
+		t.Error("want error for malformed summary template")
+	}
+
+	if _, err := ParseTemplates("", "{{unknownFunc .Name}}", nil); err == nil {
+		t.Error("want error for unknown report template function")
+	}
+
+	templates, err := ParseTemplates("", "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if templates.Summary != nil || templates.Report != nil {
+		t.Error("want no templates for empty values")
+	}
+}
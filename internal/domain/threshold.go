@@ -0,0 +1,335 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-whois
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/atc0005/go-nagios"
+)
+
+// ErrInvalidThreshold indicates that a given threshold value could not be
+// parsed.
+var ErrInvalidThreshold = errors.New("invalid threshold")
+
+// day is the duration of a (calendar agnostic) day.
+const day = 24 * time.Hour
+
+// maxThresholdDuration is the largest duration which may be specified for a
+// simple threshold.
+const maxThresholdDuration = time.Duration(math.MaxInt64)
+
+// thresholdDurationRegex matches a sequence of one or more duration
+// components (e.g., "2w", "3d12h", "1.5d").
+var thresholdDurationRegex = regexp.MustCompile(`^(?:\d+(?:\.\d+)?[wdhms])+$`)
+
+// thresholdDurationComponentRegex matches a single duration component.
+var thresholdDurationComponentRegex = regexp.MustCompile(`(\d+(?:\.\d+)?)([wdhms])`)
+
+// thresholdDurationUnits maps supported duration unit suffixes to durations.
+var thresholdDurationUnits = map[string]time.Duration{
+	"w": 7 * day,
+	"d": day,
+	"h": time.Hour,
+	"m": time.Minute,
+	"s": time.Second,
+}
+
+// Threshold represents a user-specified threshold evaluated against a value
+// expressed in (fractional) days.
+//
+// A threshold is provided in one of two forms:
+//
+//   - a simple threshold given as a whole number of days (e.g., "30") or as
+//     a duration (e.g., "72h", "2w" or "3d12h"); the threshold is crossed
+//     when the evaluated value is less than the given amount
+//   - a Nagios range (e.g., "10:", "@0:3.5", "~:20") in days, evaluated as
+//     described by the Nagios Plugin Development Guidelines
+//
+// Simple thresholds are converted to the equivalent Nagios range (e.g., "30"
+// becomes "30:") so that state evaluation and emitted performance data
+// thresholds always agree. The range boundary is rounded up to two decimal
+// places (hundredths of a day, just under 15 minutes); a duration which is
+// not a whole number of hundredths of a day is crossed up to 15 minutes
+// earlier than specified (e.g., "1h" becomes "0.05:", or 72 minutes).
+type Threshold struct {
+
+	// input is the original user-provided value.
+	input string
+
+	// rangeSpec is the Nagios range used to evaluate the threshold.
+	rangeSpec string
+
+	// duration is the parsed duration for simple thresholds.
+	duration time.Duration
+
+	// isRange indicates whether the threshold was provided using Nagios
+	// range syntax.
+	isRange bool
+}
+
+// ParseThreshold parses the given input as a simple threshold (whole number
+// of days or duration) or as a Nagios range. An error is returned if the
+// input is not in a supported format.
+func ParseThreshold(input string) (Threshold, error) {
+	input = strings.TrimSpace(input)
+
+	if input == "" {
+		return Threshold{}, fmt.Errorf(
+			"%w: empty value: %w",
+			ErrInvalidThreshold,
+			ErrMissingValue,
+		)
+	}
+
+	// Nagios range syntax.
+	if strings.ContainsAny(input, ":@~") {
+		if nagios.ParseRangeString(input) == nil {
+			return Threshold{}, fmt.Errorf(
+				"%w: %q is not a valid Nagios range",
+				ErrInvalidThreshold,
+				input,
+			)
+		}
+
+		return Threshold{
+			input:     input,
+			rangeSpec: input,
+			isRange:   true,
+		}, nil
+	}
+
+	duration, err := parseThresholdDuration(input)
+	if err != nil {
+		return Threshold{}, err
+	}
+
+	return Threshold{
+		input:     input,
+		rangeSpec: formatRangeBound(duration) + ":",
+		duration:  duration,
+	}, nil
+}
+
+// parseThresholdDuration parses a whole number of days or a duration value
+// using w (weeks), d (days), h (hours), m (minutes) and s (seconds) units.
+// Values which exceed the maximum supported duration (roughly 292 years) are
+// rejected.
+func parseThresholdDuration(input string) (time.Duration, error) {
+	if days, err := strconv.Atoi(input); err == nil {
+		switch {
+		case days < 0:
+			return 0, fmt.Errorf(
+				"%w: negative number of days %d",
+				ErrInvalidThreshold,
+				days,
+			)
+		case int64(days) > int64(maxThresholdDuration/day):
+			return 0, fmt.Errorf(
+				"%w: %d days exceeds the maximum supported duration",
+				ErrInvalidThreshold,
+				days,
+			)
+		}
+
+		return time.Duration(days) * day, nil
+	}
+
+	if !thresholdDurationRegex.MatchString(input) {
+		return 0, fmt.Errorf(
+			"%w: %q is not a number of days, duration or Nagios range",
+			ErrInvalidThreshold,
+			input,
+		)
+	}
+
+	var duration time.Duration
+	for _, component := range thresholdDurationComponentRegex.FindAllStringSubmatch(input, -1) {
+		amount, err := strconv.ParseFloat(component[1], 64)
+		if err != nil {
+			return 0, fmt.Errorf(
+				"%w: failed to parse %q: %w",
+				ErrInvalidThreshold,
+				component[0],
+				err,
+			)
+		}
+
+		value := amount * float64(thresholdDurationUnits[component[2]])
+		if value >= float64(maxThresholdDuration-duration) {
+			return 0, fmt.Errorf(
+				"%w: %q exceeds the maximum supported duration",
+				ErrInvalidThreshold,
+				input,
+			)
+		}
+
+		duration += time.Duration(value)
+	}
+
+	return duration, nil
+}
+
+// Set parses and applies the given threshold value. This method satisfies
+// the flag.Value interface.
+func (t *Threshold) Set(value string) error {
+	threshold, err := ParseThreshold(value)
+	if err != nil {
+		return err
+	}
+
+	*t = threshold
+
+	return nil
+}
+
+// String provides the original threshold value. This method satisfies the
+// flag.Value interface.
+func (t Threshold) String() string {
+	return t.input
+}
+
+// IsSet indicates whether a threshold value has been provided.
+func (t Threshold) IsSet() bool {
+	return t.rangeSpec != ""
+}
+
+// IsRange indicates whether the threshold was provided using Nagios range
+// syntax instead of as a simple threshold.
+func (t Threshold) IsRange() bool {
+	return t.isRange
+}
+
+// Duration provides the duration for a simple threshold. Zero is returned
+// for thresholds provided using Nagios range syntax.
+func (t Threshold) Duration() time.Duration {
+	return t.duration
+}
+
+// Range provides the Nagios range (in days) used to evaluate the threshold.
+// This value is suitable for use as a performance data Warn or Crit value.
+func (t Threshold) Range() string {
+	return t.rangeSpec
+}
+
+// Crossed indicates whether the given value (in days, formatted using
+// FormatDays) crosses the threshold. False is returned if the threshold is
+// not set.
+func (t Threshold) Crossed(value string) bool {
+	if !t.IsSet() {
+		return false
+	}
+
+	r := nagios.ParseRangeString(t.rangeSpec)
+	if r == nil {
+		return false
+	}
+
+	return r.CheckRange(value)
+}
+
+// DescribeUntil provides a human readable description of the threshold when
+// applied to the number of days remaining until an event for the given
+// subject (e.g., "Expires"). The reference time is used to calculate the
+// date associated with a simple threshold, displayed using the given date
+// format.
+func (t Threshold) DescribeUntil(subject string, reference time.Time, format DateFormat) string {
+	return t.describe(subject, "before", "remaining", reference.Add(t.duration), format)
+}
+
+// DescribeSince provides a human readable description of the threshold when
+// applied to the number of days elapsed since an event for the given subject
+// (e.g., "Updated"). The reference time is used to calculate the date
+// associated with a simple threshold, displayed using the given date format.
+func (t Threshold) DescribeSince(subject string, reference time.Time, format DateFormat) string {
+	return t.describe(subject, "after", "elapsed", reference.Add(-t.duration), format)
+}
+
+// describe provides a human readable description of the threshold using the
+// given subject, relation to the boundary date (simple thresholds) and
+// description of the evaluated days value (Nagios ranges).
+func (t Threshold) describe(subject string, relation string, days string, boundary time.Time, format DateFormat) string {
+	switch {
+	case !t.IsSet():
+		return "not set"
+
+	case t.isRange:
+		r := nagios.ParseRangeString(t.rangeSpec)
+		alertOn := "outside"
+		if r != nil && r.AlertOn == "INSIDE" {
+			alertOn = "inside"
+		}
+
+		return fmt.Sprintf(
+			"%s: days %s %s range %s",
+			subject,
+			days,
+			alertOn,
+			t.rangeSpec,
+		)
+
+	default:
+		return fmt.Sprintf(
+			"%s %s %v (%s)",
+			subject,
+			relation,
+			format.Format(boundary),
+			t.durationText(),
+		)
+	}
+}
+
+// durationText provides the original simple threshold value, expressed as a
+// number of days if a whole number of days was given.
+func (t Threshold) durationText() string {
+	if _, err := strconv.Atoi(t.input); err == nil {
+		return t.input + " days"
+	}
+
+	return t.input
+}
+
+// FormatDays formats a fractional number of days for use as a performance
+// data value evaluated against thresholds. Values are truncated to two decimal places (an
+// increment of just under 15 minutes).
+func FormatDays(days float64) string {
+	truncated := math.Trunc(days*100) / 100
+
+	// Avoid emitting "-0.00" for values just short of zero.
+	if truncated == 0 {
+		truncated = 0
+	}
+
+	return strconv.FormatFloat(truncated, 'f', 2, 64)
+}
+
+// formatRangeBound formats a duration as a fractional number of days for use
+// as a Nagios range boundary. Values are rounded up to two decimal places so
+// that short durations (e.g., 10m) do not become a zero boundary and the
+// threshold is never crossed later than specified; evaluated values are
+// truncated by FormatDays. Trailing zeros are omitted (e.g., "30" instead of
+// "30.00").
+func formatRangeBound(d time.Duration) string {
+	// Integer arithmetic avoids floating point error for exact values.
+	const hundredth = day / 100
+	hundredths := (d + hundredth - 1) / hundredth
+
+	return strconv.FormatFloat(float64(hundredths)/100, 'f', -1, 64)
+}
+
+// daysBetween provides the fractional number of days from the start time
+// until the end time. The value is negative if end is before start.
+func daysBetween(start time.Time, end time.Time) float64 {
+	return end.Sub(start).Hours() / 24
+}
@@ -0,0 +1,220 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-whois
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package domain
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestParseThreshold asserts that simple thresholds are converted to the
+// equivalent Nagios range and that Nagios ranges are used as-is.
+func TestParseThreshold(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		input     string
+		wantRange string
+		wantErr   bool
+	}{
+		"whole days":          {input: "30", wantRange: "30:"},
+		"zero days":           {input: "0", wantRange: "0:"},
+		"hours":               {input: "72h", wantRange: "3:"},
+		"weeks":               {input: "2w", wantRange: "14:"},
+		"days and hours":      {input: "3d12h", wantRange: "3.5:"},
+		"fractional days":     {input: "1.5d", wantRange: "1.5:"},
+		"single hour":         {input: "1h", wantRange: "0.05:"},
+		"ten minutes":         {input: "10m", wantRange: "0.01:"},
+		"range":               {input: "10:", wantRange: "10:"},
+		"inverted range":      {input: "@0:3.5", wantRange: "@0:3.5"},
+		"negative infinity":   {input: "~:20", wantRange: "~:20"},
+		"empty":               {input: "", wantErr: true},
+		"negative days":       {input: "-5", wantErr: true},
+		"unknown unit":        {input: "3y", wantErr: true},
+		"invalid range":       {input: "20:10", wantErr: true},
+		"trailing characters": {input: "3dx", wantErr: true},
+		"too many days":       {input: "200000", wantErr: true},
+		"too many weeks":      {input: "20000w", wantErr: true},
+		"duration overflow":   {input: "100000d100000d", wantErr: true},
+		"maximum days":        {input: "106751", wantRange: "106751:"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ParseThreshold(tt.input)
+			switch {
+			case tt.wantErr && !errors.Is(err, ErrInvalidThreshold):
+				t.Fatalf("want ErrInvalidThreshold for input %q, got %v", tt.input, err)
+			case !tt.wantErr && err != nil:
+				t.Fatalf("unexpected error for input %q: %v", tt.input, err)
+			}
+
+			if got.Range() != tt.wantRange {
+				t.Errorf("\nwant %q\ngot %q", tt.wantRange, got.Range())
+			}
+		})
+	}
+}
+
+// TestShortThresholdsCrossedBeforeExpiration asserts that duration
+// thresholds shorter than the precision of the range boundary are crossed
+// before the domain expires. Range boundaries are rounded up to hundredths
+// of a day, so a 1h threshold is crossed with less than 72 minutes
+// remaining.
+func TestShortThresholdsCrossedBeforeExpiration(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		threshold   string
+		remaining   time.Duration
+		wantCrossed bool
+	}{
+		"ten minutes with five minutes remaining":      {threshold: "10m", remaining: 5 * time.Minute, wantCrossed: true},
+		"ten minutes with one hour remaining":          {threshold: "10m", remaining: time.Hour},
+		"one hour with fifty minutes remaining":        {threshold: "1h", remaining: 50 * time.Minute, wantCrossed: true},
+		"one hour with exactly one hour remaining":     {threshold: "1h", remaining: time.Hour, wantCrossed: true},
+		"one hour with seventy minutes remaining":      {threshold: "1h", remaining: 70 * time.Minute, wantCrossed: true},
+		"one hour with seventy two minutes remaining":  {threshold: "1h", remaining: 72 * time.Minute},
+		"one hour with two hours remaining":            {threshold: "1h", remaining: 2 * time.Hour},
+		"thirty days with thirty days remaining":       {threshold: "30", remaining: 30 * 24 * time.Hour},
+		"thirty days with just under thirty days left": {threshold: "30", remaining: 30*24*time.Hour - time.Minute, wantCrossed: true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			threshold, err := ParseThreshold(tt.threshold)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			value := FormatDays(tt.remaining.Hours() / 24)
+			if got := threshold.Crossed(value); got != tt.wantCrossed {
+				t.Errorf("want crossed %t for %s (range %s), got %t",
+					tt.wantCrossed, value, threshold.Range(), got)
+			}
+		})
+	}
+}
+
+// TestStateAgreesWithThresholdRanges asserts that hour-granular thresholds
+// are honored and that the evaluated service state agrees with the emitted
+// threshold ranges.
+func TestStateAgreesWithThresholdRanges(t *testing.T) {
+	t.Parallel()
+
+	mustParse := func(input string) Threshold {
+		t.Helper()
+		threshold, err := ParseThreshold(input)
+		if err != nil {
+			t.Fatalf("failed to parse threshold %q: %v", input, err)
+		}
+		return threshold
+	}
+
+	tests := map[string]struct {
+		remaining time.Duration
+		warning   string
+		critical  string
+		want      string
+	}{
+		"OK with whole days":           {remaining: 40 * day, warning: "30", critical: "15", want: "OK"},
+		"WARNING with whole days":      {remaining: 20 * day, warning: "30", critical: "15", want: "WARNING"},
+		"CRITICAL with whole days":     {remaining: 10 * day, warning: "30", critical: "15", want: "CRITICAL"},
+		"WARNING with hours":           {remaining: 80 * time.Hour, warning: "4d", critical: "72h", want: "WARNING"},
+		"CRITICAL with hours":          {remaining: 70 * time.Hour, warning: "4d", critical: "72h", want: "CRITICAL"},
+		"CRITICAL within partial day":  {remaining: 3*day + 6*time.Hour, warning: "1w", critical: "3d12h", want: "CRITICAL"},
+		"WARNING inside range":         {remaining: 5 * day, warning: "@0:7", critical: "@0:2", want: "WARNING"},
+		"OK outside inverted range":    {remaining: 9 * day, warning: "@0:7", critical: "@0:2", want: "OK"},
+		"CRITICAL once expired":        {remaining: -time.Hour, warning: "30", critical: "15", want: "CRITICAL"},
+		"CRITICAL once expired ranges": {remaining: -time.Hour, warning: "@10:20", critical: "@5:6", want: "CRITICAL"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			m := Metadata{
+				Name:                 "example.com",
+				ExpirationDate:       time.Now().Add(tt.remaining),
+				AgeWarningThreshold:  mustParse(tt.warning),
+				AgeCriticalThreshold: mustParse(tt.critical),
+			}
+
+			if got := m.ServiceState().Label; got != tt.want {
+				t.Errorf("\nwant %q\ngot %q (value %s, warning %s, critical %s)",
+					tt.want, got, m.ExpirationValue(),
+					m.AgeWarningThreshold.Range(), m.AgeCriticalThreshold.Range())
+			}
+		})
+	}
+}
+
+// TestUpdatedAndCreatedThresholdsAffectState asserts that the optional
+// updated and created date thresholds are evaluated and reflected in the
+// one-line summary when crossed.
+func TestUpdatedAndCreatedThresholdsAffectState(t *testing.T) {
+	t.Parallel()
+
+	mustParse := func(input string) Threshold {
+		t.Helper()
+		threshold, err := ParseThreshold(input)
+		if err != nil {
+			t.Fatalf("failed to parse threshold %q: %v", input, err)
+		}
+		return threshold
+	}
+
+	now := time.Now()
+
+	tests := map[string]struct {
+		updatedAgo  time.Duration
+		createdAgo  time.Duration
+		want        string
+		wantSummary string
+	}{
+		"OK":                {updatedAgo: 30 * day, createdAgo: 400 * day, want: "OK"},
+		"recently updated":  {updatedAgo: 2 * day, createdAgo: 400 * day, want: "WARNING", wantSummary: "last updated"},
+		"just updated":      {updatedAgo: time.Hour, createdAgo: 400 * day, want: "CRITICAL", wantSummary: "last updated"},
+		"recently created":  {updatedAgo: 30 * day, createdAgo: 20 * day, want: "WARNING", wantSummary: "created"},
+		"lookalike created": {updatedAgo: 30 * day, createdAgo: 2 * day, want: "CRITICAL", wantSummary: "created"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			m := Metadata{
+				Name:                     "example.com",
+				ExpirationDate:           now.Add(365 * day),
+				UpdatedDate:              now.Add(-tt.updatedAgo),
+				CreatedDate:              now.Add(-tt.createdAgo),
+				AgeWarningThreshold:      mustParse("30"),
+				AgeCriticalThreshold:     mustParse("15"),
+				UpdatedWarningThreshold:  mustParse("7"),
+				UpdatedCriticalThreshold: mustParse("1"),
+				CreatedWarningThreshold:  mustParse("30"),
+				CreatedCriticalThreshold: mustParse("7"),
+			}
+
+			if got := m.ServiceState().Label; got != tt.want {
+				t.Errorf("\nwant %q\ngot %q", tt.want, got)
+			}
+
+			summary := m.OneLineCheckSummary()
+			if tt.wantSummary != "" && !strings.Contains(summary, tt.wantSummary) {
+				t.Errorf("summary %q does not contain %q", summary, tt.wantSummary)
+			}
+		})
+	}
+}
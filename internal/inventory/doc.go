@@ -0,0 +1,11 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-whois
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+// Package inventory provides support for reporting the registration details
+// of evaluated domains as an inventory in table, CSV, Markdown or HTML
+// format.
+package inventory
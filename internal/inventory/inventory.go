@@ -0,0 +1,383 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-whois
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package inventory
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/atc0005/check-whois/internal/checker"
+	"github.com/atc0005/check-whois/internal/domain"
+
+	"github.com/atc0005/go-nagios"
+)
+
+// ErrUnsupportedFormat indicates that an unsupported output format was
+// requested.
+var ErrUnsupportedFormat = errors.New("unsupported output format")
+
+// ErrUnsupportedSortKey indicates that an unsupported sort column was
+// requested.
+var ErrUnsupportedSortKey = errors.New("unsupported sort column")
+
+// Format is the output format of an inventory.
+type Format string
+
+// Supported output formats.
+const (
+	// FormatTable is a plain text table with aligned columns.
+	FormatTable Format = "table"
+
+	// FormatCSV is comma-separated values suitable for import into a
+	// spreadsheet.
+	FormatCSV Format = "csv"
+
+	// FormatMarkdown is a Markdown table.
+	FormatMarkdown Format = "markdown"
+
+	// FormatHTML is a self-contained HTML document with a sortable table.
+	FormatHTML Format = "html"
+)
+
+// SortKey is the column used to sort an inventory.
+type SortKey string
+
+// Supported sort columns.
+const (
+	// SortDomain sorts by domain name.
+	SortDomain SortKey = "domain"
+
+	// SortRegistrar sorts by registrar name, then domain name.
+	SortRegistrar SortKey = "registrar"
+
+	// SortExpires sorts by expiration date (soonest first), then domain
+	// name. Domains which could not be evaluated are listed last.
+	SortExpires SortKey = "expires"
+
+	// SortState sorts by state (most severe first), then domain name.
+	SortState SortKey = "state"
+)
+
+// emptyValue is the value displayed for unknown or unspecified details.
+const emptyValue string = "-"
+
+// SupportedFormats provides the collection of supported output formats.
+func SupportedFormats() []Format {
+	return []Format{
+		FormatTable,
+		FormatCSV,
+		FormatMarkdown,
+		FormatHTML,
+	}
+}
+
+// ParseFormat asserts that the given value is a supported output format.
+func ParseFormat(value string) (Format, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+
+	for _, format := range SupportedFormats() {
+		if string(format) == value {
+			return format, nil
+		}
+	}
+
+	return "", fmt.Errorf("%w: %q", ErrUnsupportedFormat, value)
+}
+
+// SupportedSortKeys provides the collection of supported sort columns.
+func SupportedSortKeys() []SortKey {
+	return []SortKey{
+		SortDomain,
+		SortRegistrar,
+		SortExpires,
+		SortState,
+	}
+}
+
+// ParseSortKey asserts that the given value is a supported sort column.
+func ParseSortKey(value string) (SortKey, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+
+	for _, key := range SupportedSortKeys() {
+		if string(key) == value {
+			return key, nil
+		}
+	}
+
+	return "", fmt.Errorf("%w: %q", ErrUnsupportedSortKey, value)
+}
+
+// Columns provides the inventory column headings in display order.
+func Columns() []string {
+	return []string{
+		"Domain",
+		"Registrar",
+		"Created",
+		"Updated",
+		"Expires",
+		"Days Left",
+		"State",
+		"Nameservers",
+		"DNSSEC",
+		"Locks",
+	}
+}
+
+// Row is the inventory entry for one domain.
+type Row struct {
+
+	// Name is the domain name.
+	Name string
+
+	// Domain is the evaluated domain metadata. This is nil if the domain
+	// could not be evaluated.
+	Domain *domain.Metadata
+
+	// State is the state of the domain as reported by the Nagios plugin.
+	State nagios.ServiceState
+
+	// Err records any error encountered while evaluating the domain.
+	Err error
+}
+
+// Inventory is a collection of domain inventory entries.
+type Inventory struct {
+
+	// Rows is the collection of domain inventory entries.
+	Rows []Row
+
+	// Generated is the time the inventory was generated.
+	Generated time.Time
+
+	// DateFormat is the layout and time zone used to display the time the
+	// inventory was generated. The dates of each entry are displayed using
+	// the date format of the entry's domain metadata.
+	DateFormat domain.DateFormat
+}
+
+// New creates an Inventory from the given domain names and the outcome of
+// checking each domain. The outcomes are expected in the same order as the
+// domain names. The time the inventory was generated is displayed using the
+// given date format.
+func New(names []string, outcomes []checker.Outcome, generated time.Time, format domain.DateFormat) *Inventory {
+	rows := make([]Row, 0, len(names))
+	for i, name := range names {
+		if i >= len(outcomes) {
+			break
+		}
+
+		rows = append(rows, Row{
+			Name:   name,
+			Domain: outcomes[i].Domain,
+			State:  outcomes[i].Result.State,
+			Err:    outcomes[i].Err,
+		})
+	}
+
+	return &Inventory{
+		Rows:       rows,
+		Generated:  generated,
+		DateFormat: format,
+	}
+}
+
+// Sort sorts the inventory entries by the given column. Entries with equal
+// values are sorted by domain name.
+func (inv *Inventory) Sort(key SortKey) {
+	byName := func(a Row, b Row) int {
+		return strings.Compare(a.Name, b.Name)
+	}
+
+	var compare func(a Row, b Row) int
+	switch key {
+	case SortRegistrar:
+		compare = func(a Row, b Row) int {
+			return strings.Compare(
+				strings.ToLower(a.Registrar()),
+				strings.ToLower(b.Registrar()),
+			)
+		}
+
+	case SortExpires:
+		compare = func(a Row, b Row) int {
+			switch {
+			case a.Domain == nil && b.Domain == nil:
+				return 0
+			case a.Domain == nil:
+				return 1
+			case b.Domain == nil:
+				return -1
+			default:
+				return a.Domain.ExpirationDate.Compare(b.Domain.ExpirationDate)
+			}
+		}
+
+	case SortState:
+		compare = func(a Row, b Row) int {
+			return cmp.Compare(severity(a.State), severity(b.State))
+		}
+
+	default:
+		compare = byName
+	}
+
+	slices.SortStableFunc(inv.Rows, func(a Row, b Row) int {
+		if result := compare(a, b); result != 0 {
+			return result
+		}
+
+		return byName(a, b)
+	})
+}
+
+// Failed provides the number of domains which could not be evaluated.
+func (inv *Inventory) Failed() int {
+	var failed int
+	for _, row := range inv.Rows {
+		if row.Err != nil {
+			failed++
+		}
+	}
+
+	return failed
+}
+
+// Values provides the column values for the entry in display order.
+func (r Row) Values() []string {
+	return []string{
+		r.Name,
+		r.Registrar(),
+		r.Created(),
+		r.Updated(),
+		r.Expires(),
+		r.DaysLeft(),
+		r.State.Label,
+		r.Nameservers(),
+		r.DNSSEC(),
+		r.Locks(),
+	}
+}
+
+// Registrar provides the registrar name for the domain.
+func (r Row) Registrar() string {
+	if r.Domain == nil {
+		return emptyValue
+	}
+
+	return r.Domain.RegistrarName()
+}
+
+// Created provides the date the domain was created.
+func (r Row) Created() string {
+	if r.Domain == nil {
+		return emptyValue
+	}
+
+	return formatDate(r.Domain.DateFormat, r.Domain.CreatedDate)
+}
+
+// Updated provides the date the domain registration data was last updated.
+func (r Row) Updated() string {
+	if r.Domain == nil {
+		return emptyValue
+	}
+
+	return formatDate(r.Domain.DateFormat, r.Domain.UpdatedDate)
+}
+
+// Expires provides the date the domain expires.
+func (r Row) Expires() string {
+	if r.Domain == nil {
+		return emptyValue
+	}
+
+	return formatDate(r.Domain.DateFormat, r.Domain.ExpirationDate)
+}
+
+// DaysLeft provides the whole number of days remaining until the domain
+// expires. This is a negative number if the domain has expired.
+func (r Row) DaysLeft() string {
+	days, err := domain.UntilExpiration(r.Domain)
+	if err != nil {
+		return emptyValue
+	}
+
+	return strconv.Itoa(days)
+}
+
+// Nameservers provides the comma-separated list of nameservers for the
+// domain.
+func (r Row) Nameservers() string {
+	if r.Domain == nil || r.Domain.WhoisInfo.Domain == nil ||
+		len(r.Domain.WhoisInfo.Domain.NameServers) == 0 {
+		return emptyValue
+	}
+
+	nameservers := make([]string, 0, len(r.Domain.WhoisInfo.Domain.NameServers))
+	for _, nameserver := range r.Domain.WhoisInfo.Domain.NameServers {
+		nameservers = append(nameservers, strings.ToLower(nameserver))
+	}
+
+	return strings.Join(nameservers, ", ")
+}
+
+// DNSSEC indicates whether the domain delegation is signed or unsigned.
+func (r Row) DNSSEC() string {
+	switch {
+	case r.Domain == nil || r.Domain.WhoisInfo.Domain == nil:
+		return emptyValue
+	case r.Domain.WhoisInfo.Domain.DNSSec:
+		return "signed"
+	default:
+		return "unsigned"
+	}
+}
+
+// Locks describes the registry and registrar lock statuses set for the
+// domain (e.g., "registry none, registrar complete").
+func (r Row) Locks() string {
+	if r.Domain == nil {
+		return emptyValue
+	}
+
+	return fmt.Sprintf(
+		"registry %s, registrar %s",
+		r.Domain.RegistryLockState(),
+		r.Domain.RegistrarLockState(),
+	)
+}
+
+// formatDate formats the given date for display using the given date
+// format. The empty value is provided if the date was not specified.
+func formatDate(format domain.DateFormat, date time.Time) string {
+	if date.IsZero() {
+		return emptyValue
+	}
+
+	return format.Format(date)
+}
+
+// severity ranks the given state for sorting with the most severe state
+// first.
+func severity(state nagios.ServiceState) int {
+	switch state.Label {
+	case nagios.StateCRITICALLabel:
+		return 0
+	case nagios.StateWARNINGLabel:
+		return 1
+	case nagios.StateUNKNOWNLabel:
+		return 2
+	default:
+		return 3
+	}
+}
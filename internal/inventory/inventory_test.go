@@ -0,0 +1,275 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-whois
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package inventory
+
+import (
+	"encoding/csv"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/atc0005/check-whois/internal/checker"
+	"github.com/atc0005/check-whois/internal/domain"
+
+	"github.com/atc0005/go-nagios"
+	whoisparser "github.com/likexian/whois-parser"
+)
+
+// errLookup is the error recorded for domains which could not be
+// evaluated.
+var errLookup = errors.New("lookup failed")
+
+// testInventory provides an inventory of OK, WARNING and CRITICAL domains
+// along with a domain which could not be evaluated.
+func testInventory(t *testing.T) *Inventory {
+	t.Helper()
+
+	now := time.Date(2026, time.January, 15, 0, 0, 0, 0, time.UTC)
+	statuses := append(domain.RegistrarLockStatuses(), domain.StatusServerTransferProhibited)
+	nameservers := []string{"NS1.EXAMPLE.NET", "ns2.example.net"}
+
+	// Domains without registration data could not be evaluated.
+	registrations := map[string]whoisparser.WhoisInfo{
+		"example.org": {
+			Domain: &whoisparser.Domain{
+				Domain:         "example.org",
+				CreatedDate:    "2016-03-01",
+				ExpirationDate: "2026-02-05",
+				NameServers:    nameservers,
+				DNSSec:         true,
+				Status:         statuses,
+			},
+			Registrar: &whoisparser.Contact{Name: "Zeta Registrar"},
+		},
+		"example.com": {
+			Domain: &whoisparser.Domain{
+				Domain:         "example.com",
+				CreatedDate:    "2016-03-01",
+				ExpirationDate: "2027-08-12",
+				NameServers:    nameservers,
+				DNSSec:         true,
+				Status:         statuses,
+			},
+			Registrar: &whoisparser.Contact{Name: "alpha Registrar"},
+		},
+		"example.net": {
+			Domain: &whoisparser.Domain{
+				Domain:         "example.net",
+				CreatedDate:    "2016-03-01",
+				ExpirationDate: "2026-01-20",
+				NameServers:    nameservers,
+				DNSSec:         true,
+				Status:         statuses,
+			},
+			Registrar: &whoisparser.Contact{Name: "Beta | Registrar"},
+		},
+	}
+
+	warning, err := domain.ParseThreshold("30")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	critical, err := domain.ParseThreshold("15")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	names := []string{"example.org", "example.com", "failed.com", "example.net"}
+	outcomes := make([]checker.Outcome, len(names))
+
+	for i, name := range names {
+		info, ok := registrations[name]
+		if !ok {
+			outcomes[i] = checker.Outcome{
+				Result: checker.Result{State: nagios.ServiceState{
+					Label:    nagios.StateUNKNOWNLabel,
+					ExitCode: nagios.StateUNKNOWNExitCode,
+				}},
+				Err: errLookup,
+			}
+
+			continue
+		}
+
+		m, err := domain.NewDomain(info, warning, critical)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		m.Clock = domain.FixedClock(now)
+		m.DateFormat = domain.DateFormat{Layout: "2006-01-02"}
+		outcomes[i] = checker.Outcome{
+			Domain: m,
+			Result: checker.Result{State: m.ServiceState()},
+		}
+	}
+
+	return New(names, outcomes, now, domain.DateFormat{Layout: "2006-01-02 15:04 MST"})
+}
+
+// TestSort asserts that inventory entries are sorted by the requested
+// column with ties broken by domain name.
+func TestSort(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		key  SortKey
+		want []string
+	}{
+		"domain":    {key: SortDomain, want: []string{"example.com", "example.net", "example.org", "failed.com"}},
+		"registrar": {key: SortRegistrar, want: []string{"failed.com", "example.com", "example.net", "example.org"}},
+		"expires":   {key: SortExpires, want: []string{"example.net", "example.org", "example.com", "failed.com"}},
+		"state":     {key: SortState, want: []string{"example.net", "example.org", "failed.com", "example.com"}},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			inv := testInventory(t)
+			inv.Sort(tt.key)
+
+			got := make([]string, 0, len(inv.Rows))
+			for _, row := range inv.Rows {
+				got = append(got, row.Name)
+			}
+
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("want %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+// TestValues asserts that the column values of evaluated and failed
+// domains are provided in display order.
+func TestValues(t *testing.T) {
+	t.Parallel()
+
+	inv := testInventory(t)
+	if inv.Failed() != 1 {
+		t.Errorf("want 1 failed domain, got %d", inv.Failed())
+	}
+
+	want := map[string][]string{
+		"example.org": {
+			"example.org", "Zeta Registrar", "2016-03-01", "-", "2026-02-05",
+			"21", nagios.StateWARNINGLabel, "ns1.example.net, ns2.example.net",
+			"signed", "registry partial, registrar complete",
+		},
+		"failed.com": {
+			"failed.com", "-", "-", "-", "-",
+			"-", nagios.StateUNKNOWNLabel, "-",
+			"-", "-",
+		},
+	}
+
+	for _, row := range inv.Rows {
+		wantValues, ok := want[row.Name]
+		if !ok {
+			continue
+		}
+
+		if got := row.Values(); !slices.Equal(got, wantValues) {
+			t.Errorf("%s: want %q, got %q", row.Name, wantValues, got)
+		}
+	}
+}
+
+// TestWrite asserts that the inventory is written in each supported format.
+func TestWrite(t *testing.T) {
+	t.Parallel()
+
+	tests := map[Format][]string{
+		FormatTable: {
+			"Domain       Registrar         Created     Updated  Expires",
+			"example.net  Beta | Registrar  2016-03-01  -        2026-01-20  5          CRITICAL",
+		},
+		FormatCSV: {
+			"Domain,Registrar,Created,Updated,Expires,Days Left,State,Nameservers,DNSSEC,Locks\n",
+			`example.net,Beta | Registrar,2016-03-01,-,2026-01-20,5,CRITICAL,"ns1.example.net, ns2.example.net",signed,"registry partial, registrar complete"` + "\n",
+		},
+		FormatMarkdown: {
+			"| Domain | Registrar | Created | Updated | Expires | Days Left | State | Nameservers | DNSSEC | Locks |\n| --- |",
+			`| example.net | Beta \| Registrar | 2016-03-01 |`,
+		},
+		FormatHTML: {
+			"<!DOCTYPE html>",
+			"<th>Days Left</th>",
+			`<tr class="CRITICAL"><td>example.net</td><td>Beta | Registrar</td>`,
+			`<td class="state">UNKNOWN</td>`,
+			"Generated 2026-01-15 00:00 UTC for 4 domains",
+		},
+	}
+
+	for format, want := range tests {
+		t.Run(string(format), func(t *testing.T) {
+			t.Parallel()
+
+			inv := testInventory(t)
+			inv.Sort(SortDomain)
+
+			var output strings.Builder
+			if err := inv.Write(&output, format); err != nil {
+				t.Fatal(err)
+			}
+
+			for _, value := range want {
+				if !strings.Contains(output.String(), value) {
+					t.Errorf("want output to contain %q, got:\n%s", value, output.String())
+				}
+			}
+		})
+	}
+}
+
+// TestWriteCSVRecords asserts that CSV output is parsed into one record per
+// domain with one field per column.
+func TestWriteCSVRecords(t *testing.T) {
+	t.Parallel()
+
+	var output strings.Builder
+	if err := testInventory(t).Write(&output, FormatCSV); err != nil {
+		t.Fatal(err)
+	}
+
+	records, err := csv.NewReader(strings.NewReader(output.String())).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(records) != 5 {
+		t.Fatalf("want 5 records, got %d", len(records))
+	}
+
+	for _, record := range records {
+		if len(record) != len(Columns()) {
+			t.Errorf("want %d fields, got %d: %q", len(Columns()), len(record), record)
+		}
+	}
+}
+
+// TestParseFormat asserts that only supported output formats are accepted.
+func TestParseFormat(t *testing.T) {
+	t.Parallel()
+
+	if got, err := ParseFormat(" HTML "); err != nil || got != FormatHTML {
+		t.Errorf("want %q, got %q (error: %v)", FormatHTML, got, err)
+	}
+
+	if _, err := ParseFormat("xlsx"); !errors.Is(err, ErrUnsupportedFormat) {
+		t.Errorf("want error %v, got %v", ErrUnsupportedFormat, err)
+	}
+
+	if _, err := ParseSortKey("nameservers"); !errors.Is(err, ErrUnsupportedSortKey) {
+		t.Errorf("want error %v, got %v", ErrUnsupportedSortKey, err)
+	}
+}
@@ -0,0 +1,196 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-whois
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package inventory
+
+import (
+	"encoding/csv"
+	"fmt"
+	"html/template"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+// htmlTemplate is a self-contained HTML document listing the inventory
+// entries in a table which may be sorted by clicking a column heading.
+var htmlTemplate = template.Must(template.New("inventory").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Domain Inventory</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+table { border-collapse: collapse; }
+th, td { border: 1px solid #ccc; padding: 0.3em 0.6em; text-align: left; }
+th { background: #eee; cursor: pointer; user-select: none; }
+td.number { text-align: right; }
+tr.CRITICAL td.state { background: #f8d7da; }
+tr.WARNING td.state { background: #fff3cd; }
+tr.UNKNOWN td.state { background: #e2e3e5; }
+tr.OK td.state { background: #d4edda; }
+</style>
+</head>
+<body>
+<h1>Domain Inventory</h1>
+<p>Generated {{ .Generated }} for {{ len .Rows }} domains.</p>
+<table id="inventory">
+<thead>
+<tr>{{ range .Columns }}<th>{{ . }}</th>{{ end }}</tr>
+</thead>
+<tbody>
+{{- range .Rows }}
+<tr class="{{ .State }}">
+{{- range $index, $value := .Values }}<td{{ if eq $index 5 }} class="number"{{ else if eq $index 6 }} class="state"{{ end }}>{{ $value }}</td>{{ end -}}
+</tr>
+{{- end }}
+</tbody>
+</table>
+<script>
+document.querySelectorAll("#inventory th").forEach(function (th, column) {
+  th.addEventListener("click", function () {
+    var tbody = document.querySelector("#inventory tbody");
+    var rows = Array.from(tbody.rows);
+    var ascending = th.dataset.order !== "asc";
+    var value = function (row) {
+      var text = row.cells[column].textContent;
+      var number = Number(text);
+      return text !== "-" && text !== "" && !isNaN(number) ? number : text;
+    };
+    rows.sort(function (a, b) {
+      var x = value(a), y = value(b);
+      var result = typeof x === "number" && typeof y === "number" ?
+        x - y : String(x).localeCompare(String(y));
+      return ascending ? result : -result;
+    });
+    document.querySelectorAll("#inventory th").forEach(function (other) {
+      delete other.dataset.order;
+    });
+    th.dataset.order = ascending ? "asc" : "desc";
+    rows.forEach(function (row) { tbody.appendChild(row); });
+  });
+});
+</script>
+</body>
+</html>
+`))
+
+// htmlRow is an inventory entry prepared for use in the HTML template.
+type htmlRow struct {
+	State  string
+	Values []string
+}
+
+// Write writes the inventory to the given writer in the given format.
+func (inv *Inventory) Write(w io.Writer, format Format) error {
+	switch format {
+	case FormatTable:
+		return inv.writeTable(w)
+	case FormatCSV:
+		return inv.writeCSV(w)
+	case FormatMarkdown:
+		return inv.writeMarkdown(w)
+	case FormatHTML:
+		return inv.writeHTML(w)
+	default:
+		return fmt.Errorf("%w: %q", ErrUnsupportedFormat, format)
+	}
+}
+
+// writeTable writes the inventory as a plain text table with aligned
+// columns.
+func (inv *Inventory) writeTable(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	_, _ = fmt.Fprintln(tw, strings.Join(Columns(), "\t"))
+	for _, row := range inv.Rows {
+		_, _ = fmt.Fprintln(tw, strings.Join(row.Values(), "\t"))
+	}
+
+	if err := tw.Flush(); err != nil {
+		return fmt.Errorf("failed to write inventory table: %w", err)
+	}
+
+	return nil
+}
+
+// writeCSV writes the inventory as comma-separated values.
+func (inv *Inventory) writeCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+
+	records := make([][]string, 0, len(inv.Rows)+1)
+	records = append(records, Columns())
+	for _, row := range inv.Rows {
+		records = append(records, row.Values())
+	}
+
+	if err := cw.WriteAll(records); err != nil {
+		return fmt.Errorf("failed to write inventory CSV: %w", err)
+	}
+
+	return nil
+}
+
+// writeMarkdown writes the inventory as a Markdown table.
+func (inv *Inventory) writeMarkdown(w io.Writer) error {
+	var table strings.Builder
+
+	writeRow := func(values []string) {
+		escaped := make([]string, 0, len(values))
+		for _, value := range values {
+			escaped = append(escaped, strings.ReplaceAll(value, "|", `\|`))
+		}
+
+		table.WriteString("| " + strings.Join(escaped, " | ") + " |\n")
+	}
+
+	columns := Columns()
+	writeRow(columns)
+
+	separators := make([]string, 0, len(columns))
+	for range columns {
+		separators = append(separators, "---")
+	}
+	writeRow(separators)
+
+	for _, row := range inv.Rows {
+		writeRow(row.Values())
+	}
+
+	if _, err := io.WriteString(w, table.String()); err != nil {
+		return fmt.Errorf("failed to write inventory Markdown: %w", err)
+	}
+
+	return nil
+}
+
+// writeHTML writes the inventory as a self-contained HTML document.
+func (inv *Inventory) writeHTML(w io.Writer) error {
+	rows := make([]htmlRow, 0, len(inv.Rows))
+	for _, row := range inv.Rows {
+		rows = append(rows, htmlRow{
+			State:  row.State.Label,
+			Values: row.Values(),
+		})
+	}
+
+	data := struct {
+		Generated string
+		Columns   []string
+		Rows      []htmlRow
+	}{
+		Generated: inv.DateFormat.Format(inv.Generated),
+		Columns:   Columns(),
+		Rows:      rows,
+	}
+
+	if err := htmlTemplate.Execute(w, data); err != nil {
+		return fmt.Errorf("failed to write inventory HTML: %w", err)
+	}
+
+	return nil
+}
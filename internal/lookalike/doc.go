@@ -0,0 +1,10 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-whois
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+// Package lookalike provides types and functions used to generate lookalike
+// (typosquat) permutations of a domain name.
+package lookalike
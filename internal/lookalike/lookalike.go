@@ -0,0 +1,284 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-whois
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package lookalike
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/atc0005/check-whois/internal/domain"
+)
+
+// ErrUnsupportedKind indicates that an unsupported permutation kind was
+// requested.
+var ErrUnsupportedKind = errors.New("unsupported permutation kind")
+
+// Kind identifies the type of permutation used to generate a lookalike
+// domain name.
+type Kind string
+
+// Supported permutation kinds.
+const (
+	// KindOmission removes a single character (e.g., "exmple.com").
+	KindOmission Kind = "omission"
+
+	// KindSwap transposes two adjacent characters (e.g., "exmaple.com").
+	KindSwap Kind = "swap"
+
+	// KindHomoglyph replaces characters with visually similar characters
+	// or character sequences (e.g., "examp1e.com", "exarnple.com" or the
+	// Cyrillic "е" in place of the Latin "e").
+	KindHomoglyph Kind = "homoglyph"
+
+	// KindTLDSwap replaces the public suffix (e.g., "example.net").
+	KindTLDSwap Kind = "tld-swap"
+
+	// KindHyphenation inserts a hyphen between two characters (e.g.,
+	// "exam-ple.com").
+	KindHyphenation Kind = "hyphenation"
+)
+
+// DefaultTLDs is the default collection of public suffixes used for TLD swap
+// permutations.
+var DefaultTLDs = []string{
+	"com", "net", "org", "info", "biz", "co", "io", "us", "xyz",
+	"online", "site", "app", "dev", "shop",
+}
+
+// homoglyphs maps characters (or character sequences) to visually similar
+// replacements.
+var homoglyphs = map[string][]string{
+	"a":  {"а"}, // Cyrillic a
+	"b":  {"d"},
+	"c":  {"с"}, // Cyrillic es
+	"d":  {"cl", "b"},
+	"e":  {"е"}, // Cyrillic ie
+	"g":  {"q"},
+	"i":  {"1", "l"},
+	"l":  {"1", "i"},
+	"m":  {"rn"},
+	"o":  {"0", "о"}, // digit zero, Cyrillic o
+	"p":  {"р"},      // Cyrillic er
+	"q":  {"g"},
+	"w":  {"vv"},
+	"x":  {"х"}, // Cyrillic ha
+	"y":  {"у"}, // Cyrillic u
+	"0":  {"o"},
+	"1":  {"l", "i"},
+	"rn": {"m"},
+	"vv": {"w"},
+	"cl": {"d"},
+}
+
+// Candidate is a lookalike domain name generated from a permutation of the
+// original domain name.
+type Candidate struct {
+
+	// Name is the ASCII (punycode) form of the lookalike domain name.
+	Name string
+
+	// Kind is the type of permutation used to generate the domain name.
+	Kind Kind
+}
+
+// SupportedKinds provides the collection of supported permutation kinds.
+func SupportedKinds() []Kind {
+	return []Kind{
+		KindOmission,
+		KindSwap,
+		KindHomoglyph,
+		KindTLDSwap,
+		KindHyphenation,
+	}
+}
+
+// ParseKind asserts that the given value is a supported permutation kind.
+func ParseKind(value string) (Kind, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+
+	for _, kind := range SupportedKinds() {
+		if string(kind) == value {
+			return kind, nil
+		}
+	}
+
+	return "", fmt.Errorf("%w: %q", ErrUnsupportedKind, value)
+}
+
+// Generate produces lookalike candidates for the given registrable domain
+// name using the requested permutation kinds. The given TLDs are used for
+// TLD swap permutations. Permutations which do not result in a valid domain
+// name are skipped, as are duplicates and the original name. Candidates are
+// returned sorted by name.
+func Generate(name string, kinds []Kind, tlds []string) ([]Candidate, error) {
+	asciiName, err := domain.ASCIIName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	registrable, err := domain.RegistrableDomain(asciiName)
+	if err != nil {
+		return nil, err
+	}
+
+	if registrable != asciiName {
+		return nil, fmt.Errorf(
+			"%q is not a registrable domain (registrable domain is %q): %w",
+			asciiName,
+			registrable,
+			domain.ErrNotRegistrableDomain,
+		)
+	}
+
+	// Permutations are applied to the Unicode form of the second-level
+	// label so that homoglyphs of IDN labels are handled consistently.
+	unicodeName := domain.UnicodeName(asciiName)
+	dot := strings.IndexByte(unicodeName, '.')
+	label, suffix := unicodeName[:dot], unicodeName[dot+1:]
+
+	seen := map[string]struct{}{asciiName: {}}
+	candidates := make([]Candidate, 0, len(label)*4+len(tlds))
+
+	add := func(kind Kind, label string, suffix string) {
+		candidateName, err := domain.ASCIIName(label + "." + suffix)
+		if err != nil {
+			return
+		}
+
+		if _, ok := seen[candidateName]; ok {
+			return
+		}
+		seen[candidateName] = struct{}{}
+
+		candidates = append(candidates, Candidate{Name: candidateName, Kind: kind})
+	}
+
+	for _, kind := range kinds {
+		switch kind {
+		case KindOmission:
+			for _, permutation := range omissions(label) {
+				add(kind, permutation, suffix)
+			}
+
+		case KindSwap:
+			for _, permutation := range swaps(label) {
+				add(kind, permutation, suffix)
+			}
+
+		case KindHomoglyph:
+			for _, permutation := range homoglyphSubstitutions(label) {
+				add(kind, permutation, suffix)
+			}
+
+		case KindTLDSwap:
+			for _, tld := range tlds {
+				add(kind, label, strings.Trim(strings.ToLower(tld), ". "))
+			}
+
+		case KindHyphenation:
+			for _, permutation := range hyphenations(label) {
+				add(kind, permutation, suffix)
+			}
+
+		default:
+			return nil, fmt.Errorf("%w: %q", ErrUnsupportedKind, kind)
+		}
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].Name < candidates[j].Name
+	})
+
+	return candidates, nil
+}
+
+// omissions provides permutations of the label with a single character
+// removed.
+func omissions(label string) []string {
+	runes := []rune(label)
+	permutations := make([]string, 0, len(runes))
+
+	// Removing the only character does not leave a usable label.
+	if len(runes) < 2 {
+		return permutations
+	}
+
+	for i := range runes {
+		permutations = append(permutations, string(runes[:i])+string(runes[i+1:]))
+	}
+
+	return permutations
+}
+
+// swaps provides permutations of the label with two adjacent characters
+// transposed.
+func swaps(label string) []string {
+	runes := []rune(label)
+	permutations := make([]string, 0, len(runes))
+
+	for i := 0; i < len(runes)-1; i++ {
+		if runes[i] == runes[i+1] {
+			continue
+		}
+
+		swapped := make([]rune, len(runes))
+		copy(swapped, runes)
+		swapped[i], swapped[i+1] = swapped[i+1], swapped[i]
+
+		permutations = append(permutations, string(swapped))
+	}
+
+	return permutations
+}
+
+// hyphenations provides permutations of the label with a hyphen inserted
+// between two characters.
+func hyphenations(label string) []string {
+	runes := []rune(label)
+	permutations := make([]string, 0, len(runes))
+
+	for i := 1; i < len(runes); i++ {
+		if runes[i-1] == '-' || runes[i] == '-' {
+			continue
+		}
+
+		permutations = append(permutations, string(runes[:i])+"-"+string(runes[i:]))
+	}
+
+	return permutations
+}
+
+// homoglyphSubstitutions provides permutations of the label with a single
+// character (or character sequence) replaced by a visually similar
+// replacement.
+func homoglyphSubstitutions(label string) []string {
+	var permutations []string
+
+	for original, replacements := range homoglyphs {
+		for offset := 0; ; {
+			i := strings.Index(label[offset:], original)
+			if i < 0 {
+				break
+			}
+			i += offset
+
+			for _, replacement := range replacements {
+				permutations = append(
+					permutations,
+					label[:i]+replacement+label[i+len(original):],
+				)
+			}
+
+			offset = i + len(original)
+		}
+	}
+
+	return permutations
+}
@@ -0,0 +1,73 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-whois
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package lookalike
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/atc0005/check-whois/internal/domain"
+)
+
+// TestGenerateProducesExpectedPermutations asserts that each permutation
+// kind produces the expected lookalike candidates.
+func TestGenerateProducesExpectedPermutations(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		kind Kind
+		want []string
+	}{
+		"omission":    {kind: KindOmission, want: []string{"xample.com", "exmple.com", "exampl.com"}},
+		"swap":        {kind: KindSwap, want: []string{"xeample.com", "exmaple.com", "examlpe.com"}},
+		"homoglyph":   {kind: KindHomoglyph, want: []string{"examp1e.com", "exarnple.com", "xn--xample-2of.com"}},
+		"tld-swap":    {kind: KindTLDSwap, want: []string{"example.net", "example.org"}},
+		"hyphenation": {kind: KindHyphenation, want: []string{"e-xample.com", "exam-ple.com", "exampl-e.com"}},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			candidates, err := Generate("example.com", []Kind{tt.kind}, []string{"com", "net", "org"})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			generated := make(map[string]Kind, len(candidates))
+			for _, candidate := range candidates {
+				generated[candidate.Name] = candidate.Kind
+			}
+
+			if _, ok := generated["example.com"]; ok {
+				t.Errorf("original domain included in candidates")
+			}
+
+			for _, want := range tt.want {
+				kind, ok := generated[want]
+				switch {
+				case !ok:
+					t.Errorf("want candidate %q, not found in %v", want, candidates)
+				case kind != tt.kind:
+					t.Errorf("want kind %q for candidate %q, got %q", tt.kind, want, kind)
+				}
+			}
+		})
+	}
+}
+
+// TestGenerateRejectsNonRegistrableDomains asserts that lookalike candidates
+// are only generated for registrable domains.
+func TestGenerateRejectsNonRegistrableDomains(t *testing.T) {
+	t.Parallel()
+
+	_, err := Generate("www.example.com", SupportedKinds(), DefaultTLDs)
+	if !errors.Is(err, domain.ErrNotRegistrableDomain) {
+		t.Errorf("want ErrNotRegistrableDomain, got %v", err)
+	}
+}
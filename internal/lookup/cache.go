@@ -0,0 +1,158 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-whois
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package lookup
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// cacheFileExtension is the file extension used for on-disk cache entries.
+const cacheFileExtension string = ".json"
+
+// Cache wraps another Lookup, reusing previously retrieved registration data
+// until it is older than the configured TTL. Entries are held in memory and
+// (optionally) persisted to a directory so that they may be reused across
+// plugin executions.
+type Cache struct {
+
+	// next is the Lookup used to retrieve registration data which is not
+	// cached or whose cached entry has expired.
+	next Lookup
+
+	// ttl is the maximum age of a cached entry.
+	ttl time.Duration
+
+	// dir is the optional directory used to persist cache entries.
+	dir string
+
+	// now provides the current time. This is overridden by tests.
+	now func() time.Time
+
+	// mu guards entries.
+	mu sync.Mutex
+
+	// entries holds cached registration data indexed by domain name.
+	entries map[string]RawResult
+}
+
+// NewCache creates a new Cache wrapping the given Lookup. Cached entries are
+// reused until they are older than the given TTL. If specified, entries are
+// also persisted to (and read from) the given directory.
+func NewCache(next Lookup, ttl time.Duration, dir string) *Cache {
+	return &Cache{
+		next:    next,
+		ttl:     ttl,
+		dir:     dir,
+		now:     time.Now,
+		entries: make(map[string]RawResult),
+	}
+}
+
+// Fetch provides cached registration data for the given domain name if
+// available and not expired, otherwise the data is retrieved using the
+// wrapped Lookup and cached. Only successful lookups are cached.
+func (c *Cache) Fetch(ctx context.Context, domain string) (RawResult, error) {
+	key := strings.ToLower(domain)
+
+	if raw, ok := c.get(key); ok {
+		return raw, nil
+	}
+
+	raw, err := c.next.Fetch(ctx, domain)
+	if err != nil {
+		return RawResult{}, err
+	}
+
+	if raw.Retrieved.IsZero() {
+		raw.Retrieved = c.now()
+	}
+
+	c.put(key, raw)
+
+	return raw, nil
+}
+
+// get provides the unexpired cache entry for the given key.
+func (c *Cache) get(key string) (RawResult, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if raw, ok := c.entries[key]; ok && c.fresh(raw) {
+		return raw, true
+	}
+
+	if c.dir == "" || !validFilename(key) {
+		return RawResult{}, false
+	}
+
+	data, err := os.ReadFile(filepath.Join(c.dir, key+cacheFileExtension))
+	if err != nil {
+		return RawResult{}, false
+	}
+
+	var raw RawResult
+	if err := json.Unmarshal(data, &raw); err != nil || !c.fresh(raw) {
+		return RawResult{}, false
+	}
+
+	c.entries[key] = raw
+
+	return raw, true
+}
+
+// put records the given registration data as the cache entry for the given
+// key. Persisting the entry to disk is best effort; failure to do so does
+// not affect the lookup.
+func (c *Cache) put(key string, raw RawResult) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[key] = raw
+
+	if c.dir == "" || !validFilename(key) {
+		return
+	}
+
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return
+	}
+
+	if err := os.MkdirAll(c.dir, 0o750); err != nil {
+		return
+	}
+
+	// Write to a temporary file first so that concurrent readers do not see
+	// a partially written entry.
+	tmp, err := os.CreateTemp(c.dir, key+".*.tmp")
+	if err != nil {
+		return
+	}
+
+	_, writeErr := tmp.Write(data)
+	closeErr := tmp.Close()
+	if writeErr != nil || closeErr != nil {
+		_ = os.Remove(tmp.Name())
+		return
+	}
+
+	if err := os.Rename(tmp.Name(), filepath.Join(c.dir, key+cacheFileExtension)); err != nil {
+		_ = os.Remove(tmp.Name())
+	}
+}
+
+// fresh indicates whether the given cache entry is younger than the TTL.
+func (c *Cache) fresh(raw RawResult) bool {
+	return c.now().Sub(raw.Retrieved) < c.ttl
+}
@@ -0,0 +1,164 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-whois
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package lookup
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// countingLookup is a Lookup which records the number of fetches performed.
+type countingLookup struct {
+	fetches int
+	err     error
+}
+
+// Fetch provides static registration data for the given domain.
+func (cl *countingLookup) Fetch(_ context.Context, domain string) (RawResult, error) {
+	cl.fetches++
+	if cl.err != nil {
+		return RawResult{}, cl.err
+	}
+
+	return RawResult{
+		Domain: domain,
+		Format: FormatWHOIS,
+		Source: "test",
+		Data:   "Domain Name: " + domain,
+	}, nil
+}
+
+// TestCacheReusesEntriesUntilExpired asserts that cached entries are reused
+// (including across Cache instances sharing a directory) until they are
+// older than the TTL.
+func TestCacheReusesEntriesUntilExpired(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	next := &countingLookup{}
+
+	cache := NewCache(next, time.Hour, dir)
+	cache.now = func() time.Time { return now }
+
+	ctx := context.Background()
+
+	for range 2 {
+		if _, err := cache.Fetch(ctx, "example.com"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if next.fetches != 1 {
+		t.Errorf("want 1 fetch, got %d", next.fetches)
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "example.com.json")); err != nil {
+		t.Errorf("cache entry not persisted: %v", err)
+	}
+
+	// A new cache using the same directory reuses the persisted entry.
+	other := NewCache(next, time.Hour, dir)
+	other.now = func() time.Time { return now.Add(30 * time.Minute) }
+
+	raw, err := other.Fetch(ctx, "example.com")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if next.fetches != 1 || raw.Data != "Domain Name: example.com" {
+		t.Errorf("persisted cache entry not used (fetches: %d, data: %q)", next.fetches, raw.Data)
+	}
+
+	// Expired entries are fetched again.
+	now = now.Add(2 * time.Hour)
+	if _, err := cache.Fetch(ctx, "example.com"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if next.fetches != 2 {
+		t.Errorf("want 2 fetches, got %d", next.fetches)
+	}
+}
+
+// TestCacheDoesNotCacheErrors asserts that failed lookups are not cached.
+func TestCacheDoesNotCacheErrors(t *testing.T) {
+	t.Parallel()
+
+	errLookup := errors.New("lookup failed")
+	next := &countingLookup{err: errLookup}
+	cache := NewCache(next, time.Hour, "")
+
+	for range 2 {
+		if _, err := cache.Fetch(context.Background(), "example.com"); !errors.Is(err, errLookup) {
+			t.Fatalf("want lookup error, got %v", err)
+		}
+	}
+
+	if next.fetches != 2 {
+		t.Errorf("want 2 fetches, got %d", next.fetches)
+	}
+}
+
+// TestFileFetch asserts that registration data is read from a single file or
+// from a per-domain file within a directory and that the format is
+// detected.
+func TestFileFetch(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	whoisPath := filepath.Join(dir, "example.com.txt")
+	if err := os.WriteFile(whoisPath, []byte("Domain Name: EXAMPLE.COM\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	rdapPath := filepath.Join(dir, "example.net.json")
+	if err := os.WriteFile(rdapPath, []byte(testRDAPResponse), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		path       string
+		domain     string
+		wantFormat Format
+		wantSource string
+		wantErr    error
+	}{
+		"single file":       {path: whoisPath, domain: "anything.org", wantFormat: FormatWHOIS, wantSource: whoisPath},
+		"directory WHOIS":   {path: dir, domain: "example.com", wantFormat: FormatWHOIS, wantSource: whoisPath},
+		"directory RDAP":    {path: dir, domain: "example.net", wantFormat: FormatRDAP, wantSource: rdapPath},
+		"directory missing": {path: dir, domain: "example.org", wantErr: os.ErrNotExist},
+		"path traversal":    {path: dir, domain: "../example.com", wantErr: os.ErrInvalid},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			raw, err := NewFile(tt.path).Fetch(context.Background(), tt.domain)
+			switch {
+			case tt.wantErr != nil:
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("want error %v, got %v", tt.wantErr, err)
+				}
+				return
+			case err != nil:
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if raw.Format != tt.wantFormat || raw.Source != tt.wantSource {
+				t.Errorf("unexpected result: format %q, source %q", raw.Format, raw.Source)
+			}
+		})
+	}
+}
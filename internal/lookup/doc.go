@@ -0,0 +1,10 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-whois
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+// Package lookup provides types and functions used to retrieve (WHOIS or
+// RDAP) registration data for a domain from a variety of sources.
+package lookup
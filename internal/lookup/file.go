@@ -0,0 +1,124 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-whois
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package lookup
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// fileExtensions is the list of file extensions tried (in order) when
+// looking for the registration data for a domain in a directory.
+var fileExtensions = []string{"", ".txt", ".whois", ".json", ".rdap"}
+
+// File retrieves previously saved registration data from a file. This is
+// intended for testing and offline evaluation of registration data.
+type File struct {
+
+	// path is either the path to a single file used for all domains or the
+	// path to a directory containing a file per domain.
+	path string
+}
+
+// NewFile creates a new File lookup using the given path. If the path is a
+// directory, registration data for a domain is read from a file named after
+// the domain (e.g., "example.com" or "example.com.txt"). Otherwise the file
+// is used for all domains. WHOIS or RDAP (JSON) data is accepted.
+func NewFile(path string) *File {
+	return &File{path: path}
+}
+
+// Fetch reads the registration data for the given domain name.
+func (f *File) Fetch(ctx context.Context, domain string) (RawResult, error) {
+	if err := ctx.Err(); err != nil {
+		return RawResult{}, err
+	}
+
+	path, err := f.resolve(domain)
+	if err != nil {
+		return RawResult{}, err
+	}
+
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return RawResult{}, fmt.Errorf(
+			"failed to read registration data for %s: %w",
+			domain,
+			err,
+		)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return RawResult{}, fmt.Errorf(
+			"failed to read registration data for %s: %w",
+			domain,
+			err,
+		)
+	}
+
+	return RawResult{
+		Domain:    domain,
+		Format:    detectFormat(string(data)),
+		Source:    path,
+		Data:      string(data),
+		Retrieved: info.ModTime(),
+	}, nil
+}
+
+// resolve provides the path to the file containing the registration data for
+// the given domain name.
+func (f *File) resolve(domain string) (string, error) {
+	info, err := os.Stat(f.path)
+	if err != nil {
+		return "", fmt.Errorf(
+			"failed to access registration data path: %w",
+			err,
+		)
+	}
+
+	if !info.IsDir() {
+		return f.path, nil
+	}
+
+	if !validFilename(domain) {
+		return "", fmt.Errorf(
+			"invalid domain name %q for registration data file: %w",
+			domain,
+			fs.ErrInvalid,
+		)
+	}
+
+	for _, ext := range fileExtensions {
+		path := filepath.Join(f.path, domain+ext)
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+	}
+
+	return "", fmt.Errorf(
+		"registration data file for %s not found in %s: %w",
+		domain,
+		f.path,
+		fs.ErrNotExist,
+	)
+}
+
+// validFilename indicates whether the given domain name is safe to use as
+// the name of a file within a directory.
+func validFilename(name string) bool {
+	return name != "" &&
+		name != "." &&
+		name != ".." &&
+		!strings.ContainsAny(name, `/\`) &&
+		filepath.Base(name) == name
+}
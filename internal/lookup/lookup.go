@@ -0,0 +1,120 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-whois
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package lookup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	whoisparser "github.com/likexian/whois-parser"
+)
+
+// ErrUnsupportedFormat indicates that registration data was provided in an
+// unsupported format.
+var ErrUnsupportedFormat = errors.New("unsupported registration data format")
+
+// ErrMalformedData indicates that registration data could not be processed
+// by the parser (e.g., a truncated or malformed registry response that the
+// WHOIS parser fails to handle).
+var ErrMalformedData = errors.New("malformed registration data")
+
+// Format is the format of raw registration data.
+type Format string
+
+const (
+	// FormatWHOIS indicates plain text WHOIS registration data.
+	FormatWHOIS Format = "whois"
+
+	// FormatRDAP indicates RDAP (JSON) registration data.
+	FormatRDAP Format = "rdap"
+)
+
+// Lookup is implemented by types which retrieve raw registration data for a
+// domain.
+type Lookup interface {
+
+	// Fetch retrieves the raw registration data for the given (ASCII) domain
+	// name.
+	Fetch(ctx context.Context, domain string) (RawResult, error)
+}
+
+// RawResult is the raw registration data retrieved for a domain.
+type RawResult struct {
+
+	// Domain is the name of the domain the registration data was retrieved
+	// for.
+	Domain string `json:"domain"`
+
+	// Format is the format of the registration data.
+	Format Format `json:"format"`
+
+	// Source describes where the registration data was retrieved from (e.g.,
+	// a WHOIS server, RDAP URL or file path).
+	Source string `json:"source"`
+
+	// Data is the raw registration data.
+	Data string `json:"data"`
+
+	// Retrieved indicates when the registration data was retrieved from the
+	// original source.
+	Retrieved time.Time `json:"retrieved"`
+
+	// ReferralHops is the number of referral queries made to retrieve the
+	// registration data (e.g., registrar WHOIS server queries following the
+	// registry query).
+	ReferralHops int `json:"referral_hops,omitempty"`
+}
+
+// Parse parses the raw registration data using the parser appropriate for
+// the data format. Parser errors (e.g., whoisparser.ErrNotFoundDomain) are
+// wrapped and returned as-is.
+func Parse(raw RawResult) (whoisparser.WhoisInfo, error) {
+	switch raw.Format {
+	case FormatWHOIS:
+		return parseWHOIS(raw.Data)
+
+	case FormatRDAP:
+		return ParseRDAP([]byte(raw.Data))
+
+	default:
+		return whoisparser.WhoisInfo{}, fmt.Errorf(
+			"%w: %q",
+			ErrUnsupportedFormat,
+			raw.Format,
+		)
+	}
+}
+
+// parseWHOIS parses the given plain text WHOIS data. The WHOIS parser
+// assumes the layout of some registry specific formats and may panic when
+// given malformed data; this is reported as an error wrapping
+// ErrMalformedData instead.
+func parseWHOIS(data string) (info whoisparser.WhoisInfo, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			info = whoisparser.WhoisInfo{}
+			err = fmt.Errorf("%w: WHOIS parser failed: %v", ErrMalformedData, r)
+		}
+	}()
+
+	return whoisparser.Parse(data)
+}
+
+// detectFormat provides the likely format of the given registration data.
+// RDAP responses are JSON objects, anything else is assumed to be WHOIS
+// data.
+func detectFormat(data string) Format {
+	if strings.HasPrefix(strings.TrimSpace(data), "{") {
+		return FormatRDAP
+	}
+
+	return FormatWHOIS
+}
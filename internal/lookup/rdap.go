@@ -0,0 +1,243 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-whois
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package lookup
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	whoisparser "github.com/likexian/whois-parser"
+)
+
+// DefaultRDAPBootstrapURL is the IANA RDAP bootstrap registry used to find
+// the RDAP server for a TLD.
+const DefaultRDAPBootstrapURL string = "https://data.iana.org/rdap/dns.json"
+
+// rdapMediaType is the media type requested for RDAP responses.
+const rdapMediaType string = "application/rdap+json"
+
+// rdapMaxResponseSize is the maximum RDAP response size (in bytes) that we
+// are willing to read.
+const rdapMaxResponseSize int64 = 4 * 1024 * 1024
+
+// rdapTimeout is the timeout applied to RDAP HTTP requests when the given
+// context does not have a deadline.
+const rdapTimeout = 30 * time.Second
+
+// ErrRDAPServerNotFound indicates that an RDAP server for a domain could not
+// be found in the RDAP bootstrap registry.
+var ErrRDAPServerNotFound = errors.New("RDAP server not found")
+
+// ErrRDAPRequestFailed indicates that an RDAP request was unsuccessful.
+var ErrRDAPRequestFailed = errors.New("RDAP request failed")
+
+// RDAP retrieves registration data using the Registration Data Access
+// Protocol.
+type RDAP struct {
+
+	// client is the HTTP client used to perform queries.
+	client *http.Client
+
+	// server is the optional RDAP server base URL used for all queries.
+	server string
+
+	// bootstrapURL is the URL of the RDAP bootstrap registry used to find
+	// the RDAP server for a TLD if a server is not specified.
+	bootstrapURL string
+
+	// bootstrapMu guards services.
+	bootstrapMu sync.Mutex
+
+	// services maps TLDs to RDAP server base URLs as retrieved from the RDAP
+	// bootstrap registry.
+	services map[string]string
+}
+
+// NewRDAP creates a new RDAP lookup using the given optional RDAP server base
+// URL (e.g., "https://rdap.verisign.com/com/v1/") for all queries. If not
+// specified, the RDAP server for each domain is found using the IANA RDAP
+// bootstrap registry.
+func NewRDAP(server string) *RDAP {
+	return &RDAP{
+		client:       &http.Client{Timeout: rdapTimeout},
+		server:       server,
+		bootstrapURL: DefaultRDAPBootstrapURL,
+	}
+}
+
+// SetBootstrapURL overrides the URL of the RDAP bootstrap registry.
+func (r *RDAP) SetBootstrapURL(url string) {
+	r.bootstrapMu.Lock()
+	defer r.bootstrapMu.Unlock()
+
+	r.bootstrapURL = url
+	r.services = nil
+}
+
+// Fetch retrieves the raw RDAP data for the given domain name. An error
+// wrapping whoisparser.ErrNotFoundDomain is returned if the RDAP server
+// reports that the domain was not found.
+func (r *RDAP) Fetch(ctx context.Context, domain string) (RawResult, error) {
+	server := r.server
+	if server == "" {
+		var err error
+		server, err = r.serverFor(ctx, domain)
+		if err != nil {
+			return RawResult{}, stepError(ctx, "RDAP bootstrap registry query", err)
+		}
+	}
+
+	url := strings.TrimSuffix(server, "/") + "/domain/" + domain
+
+	body, status, err := r.get(ctx, url)
+	if err != nil {
+		return RawResult{}, stepError(
+			ctx,
+			fmt.Sprintf("RDAP query (%s)", server),
+			fmt.Errorf("failed to query RDAP data for %s: %w", domain, err),
+		)
+	}
+
+	switch {
+	case status == http.StatusNotFound:
+		return RawResult{}, fmt.Errorf(
+			"RDAP server %s returned %d for %s: %w",
+			server,
+			status,
+			domain,
+			whoisparser.ErrNotFoundDomain,
+		)
+
+	case status != http.StatusOK:
+		return RawResult{}, fmt.Errorf(
+			"%w: RDAP server %s returned %d for %s",
+			ErrRDAPRequestFailed,
+			server,
+			status,
+			domain,
+		)
+	}
+
+	return RawResult{
+		Domain:    domain,
+		Format:    FormatRDAP,
+		Source:    url,
+		Data:      string(body),
+		Retrieved: time.Now(),
+	}, nil
+}
+
+// get performs an HTTP GET request for the given URL, returning the response
+// body and status code.
+func (r *RDAP) get(ctx context.Context, url string) ([]byte, int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	req.Header.Set("Accept", rdapMediaType+", application/json")
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, rdapMaxResponseSize))
+	if err != nil {
+		return nil, resp.StatusCode, err
+	}
+
+	return body, resp.StatusCode, nil
+}
+
+// rdapBootstrap is the RDAP bootstrap registry format described by RFC 9224.
+type rdapBootstrap struct {
+	Services [][][]string `json:"services"`
+}
+
+// serverFor uses the RDAP bootstrap registry to find the RDAP server base
+// URL for the given domain name. The registry is retrieved once and reused
+// for later lookups.
+func (r *RDAP) serverFor(ctx context.Context, domain string) (string, error) {
+	r.bootstrapMu.Lock()
+	defer r.bootstrapMu.Unlock()
+
+	if r.services == nil {
+		body, status, err := r.get(ctx, r.bootstrapURL)
+		if err != nil {
+			return "", fmt.Errorf(
+				"failed to retrieve RDAP bootstrap registry: %w",
+				err,
+			)
+		}
+
+		if status != http.StatusOK {
+			return "", fmt.Errorf(
+				"%w: RDAP bootstrap registry %s returned %d",
+				ErrRDAPRequestFailed,
+				r.bootstrapURL,
+				status,
+			)
+		}
+
+		var bootstrap rdapBootstrap
+		if err := json.Unmarshal(body, &bootstrap); err != nil {
+			return "", fmt.Errorf(
+				"failed to parse RDAP bootstrap registry: %w",
+				err,
+			)
+		}
+
+		services := make(map[string]string)
+		for _, service := range bootstrap.Services {
+			if len(service) < 2 || len(service[1]) == 0 {
+				continue
+			}
+
+			for _, tld := range service[0] {
+				services[strings.ToLower(tld)] = preferredURL(service[1])
+			}
+		}
+
+		r.services = services
+	}
+
+	// Use the longest matching entry (e.g., "co.uk" before "uk").
+	labels := strings.Split(strings.ToLower(domain), ".")
+	for i := 1; i < len(labels); i++ {
+		if server, ok := r.services[strings.Join(labels[i:], ".")]; ok {
+			return server, nil
+		}
+	}
+
+	return "", fmt.Errorf(
+		"%w: no entry for %s in RDAP bootstrap registry",
+		ErrRDAPServerNotFound,
+		domain,
+	)
+}
+
+// preferredURL provides the first HTTPS URL from the given list, falling
+// back to the first URL if none use HTTPS.
+func preferredURL(urls []string) string {
+	for _, url := range urls {
+		if strings.HasPrefix(strings.ToLower(url), "https://") {
+			return url
+		}
+	}
+
+	return urls[0]
+}
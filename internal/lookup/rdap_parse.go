@@ -0,0 +1,315 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-whois
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package lookup
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	whoisparser "github.com/likexian/whois-parser"
+)
+
+// rdapDomain is the subset of an RDAP domain object (RFC 9083) used to
+// populate WHOIS metadata.
+type rdapDomain struct {
+	ObjectClassName string        `json:"objectClassName"`
+	Handle          string        `json:"handle"`
+	LDHName         string        `json:"ldhName"`
+	UnicodeName     string        `json:"unicodeName"`
+	Status          []string      `json:"status"`
+	Events          []rdapEvent   `json:"events"`
+	Entities        []rdapEntity  `json:"entities"`
+	Nameservers     []rdapNSEntry `json:"nameservers"`
+	SecureDNS       *struct {
+		DelegationSigned bool `json:"delegationSigned"`
+	} `json:"secureDNS"`
+	Port43    string `json:"port43"`
+	ErrorCode int    `json:"errorCode"`
+}
+
+// rdapEvent is an RDAP event (e.g., registration or expiration).
+type rdapEvent struct {
+	EventAction string `json:"eventAction"`
+	EventDate   string `json:"eventDate"`
+}
+
+// rdapNSEntry is an RDAP nameserver object.
+type rdapNSEntry struct {
+	LDHName string `json:"ldhName"`
+}
+
+// rdapEntity is an RDAP entity (contact) object.
+type rdapEntity struct {
+	Handle     string          `json:"handle"`
+	Roles      []string        `json:"roles"`
+	VCardArray json.RawMessage `json:"vcardArray"`
+	PublicIDs  []struct {
+		Type       string `json:"type"`
+		Identifier string `json:"identifier"`
+	} `json:"publicIds"`
+	Entities []rdapEntity `json:"entities"`
+}
+
+// ParseRDAP parses the given RDAP domain object response, returning the
+// registration data in the same form provided by the WHOIS parser. An error
+// wrapping whoisparser.ErrNotFoundDomain is returned for RDAP error
+// responses reporting that the domain was not found.
+func ParseRDAP(data []byte) (whoisparser.WhoisInfo, error) {
+	var resp rdapDomain
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return whoisparser.WhoisInfo{}, fmt.Errorf(
+			"failed to parse RDAP response: %w: %w",
+			whoisparser.ErrDomainDataInvalid,
+			err,
+		)
+	}
+
+	switch {
+	case resp.ErrorCode == 404:
+		return whoisparser.WhoisInfo{}, whoisparser.ErrNotFoundDomain
+
+	case resp.ErrorCode != 0 ||
+		(resp.ObjectClassName != "" && resp.ObjectClassName != "domain") ||
+		resp.LDHName == "":
+		return whoisparser.WhoisInfo{}, fmt.Errorf(
+			"RDAP response is not a domain object: %w",
+			whoisparser.ErrDomainDataInvalid,
+		)
+	}
+
+	name := strings.ToLower(strings.TrimSuffix(resp.LDHName, "."))
+
+	d := whoisparser.Domain{
+		ID:          resp.Handle,
+		Domain:      name,
+		Punycode:    name,
+		WhoisServer: resp.Port43,
+		Status:      eppStatuses(resp.Status),
+	}
+
+	if unicodeName := strings.TrimSuffix(resp.UnicodeName, "."); unicodeName != "" {
+		d.Domain = strings.ToLower(unicodeName)
+	}
+
+	if i := strings.IndexByte(name, '.'); i > 0 {
+		d.Name = name[:i]
+		d.Extension = name[i+1:]
+	}
+
+	for _, ns := range resp.Nameservers {
+		if ns.LDHName != "" {
+			d.NameServers = append(
+				d.NameServers,
+				strings.ToLower(strings.TrimSuffix(ns.LDHName, ".")),
+			)
+		}
+	}
+
+	if resp.SecureDNS != nil {
+		d.DNSSec = resp.SecureDNS.DelegationSigned
+	}
+
+	for _, event := range resp.Events {
+		date, err := time.Parse(time.RFC3339, event.EventDate)
+		if err != nil {
+			continue
+		}
+
+		switch strings.ToLower(event.EventAction) {
+		case "registration":
+			d.CreatedDate = event.EventDate
+			d.CreatedDateInTime = &date
+		case "expiration":
+			d.ExpirationDate = event.EventDate
+			d.ExpirationDateInTime = &date
+		case "last changed":
+			d.UpdatedDate = event.EventDate
+			d.UpdatedDateInTime = &date
+		}
+	}
+
+	info := whoisparser.WhoisInfo{Domain: &d}
+
+	info.Registrar = findContact(resp.Entities, "registrar")
+	info.Registrant = findContact(resp.Entities, "registrant")
+	info.Administrative = findContact(resp.Entities, "administrative")
+	info.Technical = findContact(resp.Entities, "technical")
+	info.Billing = findContact(resp.Entities, "billing")
+
+	return info, nil
+}
+
+// eppStatuses converts RDAP status values (e.g., "client transfer
+// prohibited") to the equivalent EPP status codes (e.g.,
+// "clientTransferProhibited") as used in WHOIS data. See RFC 8056.
+func eppStatuses(statuses []string) []string {
+	if len(statuses) == 0 {
+		return nil
+	}
+
+	converted := make([]string, 0, len(statuses))
+	for _, status := range statuses {
+		words := strings.Fields(strings.ToLower(status))
+		if len(words) == 0 {
+			continue
+		}
+
+		// The EPP "ok" status is represented as "active" in RDAP.
+		if len(words) == 1 && words[0] == "active" {
+			converted = append(converted, "ok")
+			continue
+		}
+
+		for i := 1; i < len(words); i++ {
+			words[i] = strings.ToUpper(words[i][:1]) + words[i][1:]
+		}
+
+		converted = append(converted, strings.Join(words, ""))
+	}
+
+	return converted
+}
+
+// findContact provides the contact details for the first entity (searching
+// nested entities) with the given role. Nil is returned if no entity has the
+// role.
+func findContact(entities []rdapEntity, role string) *whoisparser.Contact {
+	for _, entity := range entities {
+		for _, entityRole := range entity.Roles {
+			if strings.EqualFold(entityRole, role) {
+				return entity.contact()
+			}
+		}
+	}
+
+	for _, entity := range entities {
+		if contact := findContact(entity.Entities, role); contact != nil {
+			return contact
+		}
+	}
+
+	return nil
+}
+
+// contact converts the RDAP entity to WHOIS contact details.
+func (e rdapEntity) contact() *whoisparser.Contact {
+	contact := whoisparser.Contact{ID: e.Handle}
+
+	for _, id := range e.PublicIDs {
+		if strings.EqualFold(id.Type, "IANA Registrar ID") {
+			contact.ID = id.Identifier
+		}
+	}
+
+	// A jCard (RFC 7095) is an array of the form ["vcard", [properties]]
+	// where each property is of the form [name, params, type, value...].
+	var vcard []json.RawMessage
+	if err := json.Unmarshal(e.VCardArray, &vcard); err != nil || len(vcard) < 2 {
+		return &contact
+	}
+
+	var properties [][]json.RawMessage
+	if err := json.Unmarshal(vcard[1], &properties); err != nil {
+		return &contact
+	}
+
+	for _, property := range properties {
+		if len(property) < 4 {
+			continue
+		}
+
+		var name string
+		if err := json.Unmarshal(property[0], &name); err != nil {
+			continue
+		}
+
+		switch strings.ToLower(name) {
+		case "fn":
+			contact.Name = jCardText(property[3])
+		case "org":
+			contact.Organization = jCardText(property[3])
+		case "email":
+			contact.Email = jCardText(property[3])
+		case "tel":
+			tel := strings.TrimPrefix(jCardText(property[3]), "tel:")
+			if jCardParamHasType(property[1], "fax") {
+				contact.Fax = tel
+				continue
+			}
+			contact.Phone = tel
+		case "adr":
+			applyJCardAddress(&contact, property[1], property[3])
+		}
+	}
+
+	return &contact
+}
+
+// jCardText provides the text value of a jCard property. Structured values
+// are joined using spaces.
+func jCardText(value json.RawMessage) string {
+	var text string
+	if err := json.Unmarshal(value, &text); err == nil {
+		return strings.TrimSpace(text)
+	}
+
+	var parts []string
+	if err := json.Unmarshal(value, &parts); err == nil {
+		return strings.TrimSpace(strings.Join(parts, " "))
+	}
+
+	return ""
+}
+
+// jCardParamHasType indicates whether the given jCard property parameters
+// include the given type.
+func jCardParamHasType(params json.RawMessage, want string) bool {
+	var decoded map[string]json.RawMessage
+	if err := json.Unmarshal(params, &decoded); err != nil {
+		return false
+	}
+
+	types, ok := decoded["type"]
+	if !ok {
+		return false
+	}
+
+	return strings.Contains(strings.ToLower(string(types)), strings.ToLower(want))
+}
+
+// applyJCardAddress applies the given jCard "adr" property value (or label
+// parameter) to the contact details.
+func applyJCardAddress(contact *whoisparser.Contact, params json.RawMessage, value json.RawMessage) {
+	var decoded map[string]json.RawMessage
+	if err := json.Unmarshal(params, &decoded); err == nil {
+		if cc, ok := decoded["cc"]; ok {
+			contact.Country = jCardText(cc)
+		}
+	}
+
+	// Structured address: [pobox, ext, street, locality, region, code,
+	// country]; each component is either a string or a list of strings.
+	var components []json.RawMessage
+	if err := json.Unmarshal(value, &components); err != nil || len(components) < 7 {
+		if label, ok := decoded["label"]; ok {
+			contact.Street = jCardText(label)
+		}
+		return
+	}
+
+	contact.Street = jCardText(components[2])
+	contact.City = jCardText(components[3])
+	contact.Province = jCardText(components[4])
+	contact.PostalCode = jCardText(components[5])
+
+	if country := jCardText(components[6]); country != "" {
+		contact.Country = country
+	}
+}
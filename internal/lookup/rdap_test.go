@@ -0,0 +1,222 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-whois
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package lookup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	whoisparser "github.com/likexian/whois-parser"
+)
+
+// testRDAPResponse is an anonymized RDAP domain object response.
+const testRDAPResponse string = `{
+  "objectClassName": "domain",
+  "handle": "2336799_DOMAIN_COM-VRSN",
+  "ldhName": "EXAMPLE.COM",
+  "status": ["client delete prohibited", "client transfer prohibited", "active"],
+  "events": [
+    {"eventAction": "registration", "eventDate": "1995-08-14T04:00:00Z"},
+    {"eventAction": "expiration", "eventDate": "2030-08-13T04:00:00Z"},
+    {"eventAction": "last changed", "eventDate": "2024-08-14T07:01:38Z"},
+    {"eventAction": "last update of RDAP database", "eventDate": "2026-01-01T00:00:00Z"}
+  ],
+  "entities": [
+    {
+      "objectClassName": "entity",
+      "handle": "376",
+      "roles": ["registrar"],
+      "publicIds": [{"type": "IANA Registrar ID", "identifier": "376"}],
+      "vcardArray": ["vcard", [
+        ["version", {}, "text", "4.0"],
+        ["fn", {}, "text", "Example Registrar, Inc."]
+      ]],
+      "entities": [
+        {
+          "roles": ["abuse"],
+          "vcardArray": ["vcard", [
+            ["version", {}, "text", "4.0"],
+            ["email", {}, "text", "abuse@registrar.example"]
+          ]]
+        }
+      ]
+    },
+    {
+      "roles": ["registrant"],
+      "vcardArray": ["vcard", [
+        ["version", {}, "text", "4.0"],
+        ["fn", {}, "text", "REDACTED FOR PRIVACY"],
+        ["org", {}, "text", "Example Org"],
+        ["adr", {"cc": "US"}, "text", ["", "", "123 Main St", "Springfield", "IL", "62701", ""]],
+        ["tel", {"type": "voice"}, "uri", "tel:+1.5555550100"],
+        ["email", {}, "text", "registrant@example.com"]
+      ]]
+    }
+  ],
+  "nameservers": [
+    {"objectClassName": "nameserver", "ldhName": "A.IANA-SERVERS.NET"},
+    {"objectClassName": "nameserver", "ldhName": "B.IANA-SERVERS.NET"}
+  ],
+  "secureDNS": {"delegationSigned": true},
+  "port43": "whois.verisign-grs.com"
+}`
+
+// TestParseRDAP asserts that RDAP domain object responses are converted to
+// WHOIS metadata.
+func TestParseRDAP(t *testing.T) {
+	t.Parallel()
+
+	info, err := ParseRDAP([]byte(testRDAPResponse))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	d := info.Domain
+	if d == nil {
+		t.Fatal("domain details not provided")
+	}
+
+	if d.Domain != "example.com" || d.Name != "example" || d.Extension != "com" {
+		t.Errorf("unexpected domain name details: %+v", d)
+	}
+
+	wantStatus := []string{"clientDeleteProhibited", "clientTransferProhibited", "ok"}
+	if !reflect.DeepEqual(d.Status, wantStatus) {
+		t.Errorf("\nwant status %q\ngot %q", wantStatus, d.Status)
+	}
+
+	wantNS := []string{"a.iana-servers.net", "b.iana-servers.net"}
+	if !reflect.DeepEqual(d.NameServers, wantNS) {
+		t.Errorf("\nwant nameservers %q\ngot %q", wantNS, d.NameServers)
+	}
+
+	if !d.DNSSec {
+		t.Error("want DNSSEC enabled")
+	}
+
+	if d.WhoisServer != "whois.verisign-grs.com" {
+		t.Errorf("unexpected WHOIS server %q", d.WhoisServer)
+	}
+
+	for label, date := range map[string]string{
+		"created":    fmt.Sprint(d.CreatedDateInTime),
+		"updated":    fmt.Sprint(d.UpdatedDateInTime),
+		"expiration": fmt.Sprint(d.ExpirationDateInTime),
+	} {
+		if date == "<nil>" {
+			t.Errorf("%s date not parsed", label)
+		}
+	}
+
+	if got := d.ExpirationDateInTime.Year(); got != 2030 {
+		t.Errorf("want expiration year 2030, got %d", got)
+	}
+
+	if info.Registrar == nil || info.Registrar.Name != "Example Registrar, Inc." || info.Registrar.ID != "376" {
+		t.Errorf("unexpected registrar details: %+v", info.Registrar)
+	}
+
+	want := &whoisparser.Contact{
+		Name:         "REDACTED FOR PRIVACY",
+		Organization: "Example Org",
+		Street:       "123 Main St",
+		City:         "Springfield",
+		Province:     "IL",
+		PostalCode:   "62701",
+		Country:      "US",
+		Phone:        "+1.5555550100",
+		Email:        "registrant@example.com",
+	}
+	if !reflect.DeepEqual(info.Registrant, want) {
+		t.Errorf("\nwant registrant %+v\ngot %+v", want, info.Registrant)
+	}
+
+	if info.Technical != nil {
+		t.Errorf("want no technical contact, got %+v", info.Technical)
+	}
+}
+
+// TestParseRDAPErrors asserts that RDAP error responses and invalid data are
+// reported using the WHOIS parser errors.
+func TestParseRDAPErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		input string
+		want  error
+	}{
+		"not found":    {input: `{"errorCode": 404, "title": "Not Found"}`, want: whoisparser.ErrNotFoundDomain},
+		"server error": {input: `{"errorCode": 500}`, want: whoisparser.ErrDomainDataInvalid},
+		"not a domain": {input: `{"objectClassName": "entity", "handle": "X"}`, want: whoisparser.ErrDomainDataInvalid},
+		"invalid JSON": {input: `{"objectClassName":`, want: whoisparser.ErrDomainDataInvalid},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := ParseRDAP([]byte(tt.input))
+			if !errors.Is(err, tt.want) {
+				t.Errorf("want error %v, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+// TestRDAPFetch asserts that the RDAP server for a domain is found using the
+// bootstrap registry and that unknown domains are reported as not found.
+func TestRDAPFetch(t *testing.T) {
+	t.Parallel()
+
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/bootstrap.json":
+			_, _ = fmt.Fprintf(
+				w,
+				`{"services": [[["net", "com"], ["%s/com/"]]]}`,
+				server.URL,
+			)
+		case "/com/domain/example.com":
+			w.Header().Set("Content-Type", rdapMediaType)
+			_, _ = fmt.Fprint(w, testRDAPResponse)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	r := NewRDAP("")
+	r.SetBootstrapURL(server.URL + "/bootstrap.json")
+
+	raw, err := r.Fetch(context.Background(), "example.com")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if raw.Format != FormatRDAP || raw.Source != server.URL+"/com/domain/example.com" {
+		t.Errorf("unexpected result details: %+v", raw)
+	}
+
+	if _, err := Parse(raw); err != nil {
+		t.Errorf("unexpected parse error: %v", err)
+	}
+
+	if _, err := r.Fetch(context.Background(), "missing.com"); !errors.Is(err, whoisparser.ErrNotFoundDomain) {
+		t.Errorf("want not found error, got %v", err)
+	}
+
+	if _, err := r.Fetch(context.Background(), "example.org"); !errors.Is(err, ErrRDAPServerNotFound) {
+		t.Errorf("want RDAP server not found error, got %v", err)
+	}
+}
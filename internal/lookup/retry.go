@@ -0,0 +1,96 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-whois
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package lookup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"time"
+
+	whoisparser "github.com/likexian/whois-parser"
+)
+
+// Retry wraps another Lookup, retrying failed lookups after a delay. Retries
+// stop once the context is done.
+type Retry struct {
+
+	// next is the Lookup used to retrieve registration data.
+	next Lookup
+
+	// retries is the number of times a failed lookup is retried.
+	retries int
+
+	// delay is the time waited before retrying a failed lookup.
+	delay time.Duration
+}
+
+// NewRetry creates a new Retry wrapping the given Lookup. Failed lookups are
+// retried up to the given number of times, waiting the given delay before
+// each retry.
+func NewRetry(next Lookup, retries int, delay time.Duration) *Retry {
+	return &Retry{
+		next:    next,
+		retries: retries,
+		delay:   delay,
+	}
+}
+
+// Fetch retrieves the registration data for the given domain name using the
+// wrapped Lookup, retrying failed lookups. Lookups which fail because the
+// domain was not found, because saved registration data is missing or
+// because the context is done are not retried.
+func (r *Retry) Fetch(ctx context.Context, domain string) (RawResult, error) {
+	var err error
+	for attempt := 0; attempt <= r.retries; attempt++ {
+		if attempt > 0 {
+			timer := time.NewTimer(r.delay)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return RawResult{}, stepError(
+					ctx,
+					fmt.Sprintf("waiting to retry lookup (attempt %d of %d)", attempt+1, r.retries+1),
+					err,
+				)
+			case <-timer.C:
+			}
+		}
+
+		var raw RawResult
+		raw, err = r.next.Fetch(ctx, domain)
+		if err == nil || !retryable(ctx, err) {
+			return raw, err
+		}
+	}
+
+	return RawResult{}, fmt.Errorf(
+		"lookup failed after %d attempts: %w",
+		r.retries+1,
+		err,
+	)
+}
+
+// retryable indicates whether a lookup which failed with the given error
+// should be retried.
+func retryable(ctx context.Context, err error) bool {
+	switch {
+	case ctx.Err() != nil,
+		errors.Is(err, context.Canceled),
+		errors.Is(err, context.DeadlineExceeded),
+		errors.Is(err, whoisparser.ErrNotFoundDomain),
+		errors.Is(err, fs.ErrNotExist),
+		errors.Is(err, fs.ErrInvalid),
+		errors.Is(err, ErrRDAPServerNotFound),
+		errors.Is(err, ErrUnsupportedFormat):
+		return false
+	}
+
+	return true
+}
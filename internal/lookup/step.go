@@ -0,0 +1,71 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-whois
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package lookup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+)
+
+// StepError records the lookup step (e.g., a referral WHOIS query) that was
+// in progress when an error occurred.
+type StepError struct {
+
+	// Step is a human readable description of the lookup step.
+	Step string
+
+	// Err is the error encountered during the step.
+	Err error
+}
+
+// Error provides the step description along with the underlying error.
+func (e *StepError) Error() string {
+	return e.Step + ": " + e.Err.Error()
+}
+
+// Unwrap provides the underlying error.
+func (e *StepError) Unwrap() error {
+	return e.Err
+}
+
+// Step provides the description of the lookup step recorded by the given
+// error. An empty string is returned if the error does not record a step.
+func Step(err error) string {
+	var stepErr *StepError
+	if errors.As(err, &stepErr) {
+		return stepErr.Step
+	}
+
+	return ""
+}
+
+// stepError wraps the given error with the description of the step in
+// progress. If the given context is done, the context error is included so
+// that callers may detect an expired deadline using errors.Is with
+// context.DeadlineExceeded; network timeouts caused by the deadline are
+// otherwise reported as os.ErrDeadlineExceeded.
+func stepError(ctx context.Context, step string, err error) error {
+	ctxErr := ctx.Err()
+
+	// Connection deadlines are set to the context deadline and may fire just
+	// before the context is marked as done.
+	if ctxErr == nil && errors.Is(err, os.ErrDeadlineExceeded) {
+		if deadline, ok := ctx.Deadline(); ok && !time.Now().Before(deadline) {
+			ctxErr = context.DeadlineExceeded
+		}
+	}
+
+	if ctxErr != nil && !errors.Is(err, ctxErr) {
+		err = fmt.Errorf("%w: %w", ctxErr, err)
+	}
+
+	return &StepError{Step: step, Err: err}
+}
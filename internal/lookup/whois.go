@@ -0,0 +1,227 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-whois
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package lookup
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/likexian/whois"
+)
+
+// defaultWHOISSource is used to describe the source of WHOIS data when a
+// specific WHOIS server is not requested.
+const defaultWHOISSource string = "default WHOIS server"
+
+// WHOIS retrieves registration data using the WHOIS protocol.
+type WHOIS struct {
+
+	// server is the optional WHOIS server used for all queries.
+	server string
+
+	// disableReferral indicates whether referral lookups are disabled.
+	disableReferral bool
+}
+
+// NewWHOIS creates a new WHOIS lookup using the given optional WHOIS server
+// for all queries. Referral lookups are performed unless disabled.
+func NewWHOIS(server string, disableReferral bool) *WHOIS {
+	return &WHOIS{
+		server:          server,
+		disableReferral: disableReferral,
+	}
+}
+
+// Fetch retrieves the raw WHOIS data for the given domain name. The given
+// context applies to all queries performed, including WHOIS server
+// discovery and referral lookups. If the context is done before the lookup
+// completes, the returned error records the query in progress.
+func (w *WHOIS) Fetch(ctx context.Context, domain string) (RawResult, error) {
+	if err := ctx.Err(); err != nil {
+		return RawResult{}, stepError(ctx, "WHOIS query", err)
+	}
+
+	dialer := &contextDialer{
+		ctx:          ctx,
+		serverGiven:  w.server != "",
+		dialer:       &net.Dialer{},
+		currentQuery: "WHOIS query",
+	}
+
+	// A client is created for each lookup so that queries are bound to the
+	// given context.
+	client := whois.NewClient()
+	client.SetDialer(dialer)
+	client.SetDisableReferral(w.disableReferral)
+
+	if deadline, ok := ctx.Deadline(); ok {
+		client.SetTimeout(time.Until(deadline))
+	}
+
+	var whoisRaw string
+	var err error
+
+	source := w.server
+	switch {
+	case w.server != "":
+		whoisRaw, err = client.Whois(domain, w.server)
+	default:
+		source = defaultWHOISSource
+		whoisRaw, err = client.Whois(domain)
+	}
+	if err != nil {
+		return RawResult{}, stepError(
+			ctx,
+			dialer.currentQuery,
+			fmt.Errorf("failed to query WHOIS data for %s: %w", domain, err),
+		)
+	}
+
+	// The WHOIS client ignores referral query failures, returning the
+	// registry response as-is. Report a referral query cut short by the
+	// context instead of evaluating incomplete data.
+	if err := ctx.Err(); err != nil {
+		return RawResult{}, stepError(
+			ctx,
+			dialer.currentQuery,
+			fmt.Errorf("failed to query WHOIS data for %s: %w", domain, err),
+		)
+	}
+
+	return RawResult{
+		Domain:       domain,
+		Format:       FormatWHOIS,
+		Source:       source,
+		Data:         whoisRaw,
+		Retrieved:    time.Now(),
+		ReferralHops: dialer.referrals(),
+	}, nil
+}
+
+// contextDialer is a dialer used by the WHOIS client to bind connections to
+// a context. The context deadline is applied to each connection and the
+// connection is closed if the context is cancelled. Each connection made
+// corresponds to a WHOIS query, allowing the query in progress to be
+// recorded.
+type contextDialer struct {
+
+	// ctx is the context used for connections.
+	ctx context.Context
+
+	// dialer is used to establish connections.
+	dialer *net.Dialer
+
+	// serverGiven indicates whether a specific WHOIS server was requested
+	// instead of using WHOIS server discovery.
+	serverGiven bool
+
+	// queries is the number of queries (connections) made.
+	queries int
+
+	// currentQuery describes the query in progress.
+	currentQuery string
+}
+
+// Dial establishes a connection to the given address using the context.
+// This method satisfies the proxy.Dialer interface.
+func (cd *contextDialer) Dial(network string, address string) (net.Conn, error) {
+	cd.queries++
+	cd.currentQuery = cd.describeQuery(address)
+
+	conn, err := cd.dialer.DialContext(cd.ctx, network, address)
+	if err != nil {
+		return nil, err
+	}
+
+	deadlineConn := &contextConn{Conn: conn, ctx: cd.ctx}
+	deadlineConn.stop = context.AfterFunc(cd.ctx, func() { _ = conn.Close() })
+
+	return deadlineConn, nil
+}
+
+// referrals provides the number of referral queries made; queries beyond
+// WHOIS server discovery and the initial WHOIS query.
+func (cd *contextDialer) referrals() int {
+	initial := 2
+	if cd.serverGiven {
+		initial = 1
+	}
+
+	return max(cd.queries-initial, 0)
+}
+
+// describeQuery provides a description of the WHOIS query performed using a
+// connection to the given address based on the number of queries made.
+func (cd *contextDialer) describeQuery(address string) string {
+	server := address
+	if host, _, err := net.SplitHostPort(address); err == nil {
+		server = host
+	}
+
+	var kind string
+	switch {
+	case !cd.serverGiven && cd.queries == 1:
+		kind = "WHOIS server discovery query"
+	case cd.serverGiven && cd.queries == 1, !cd.serverGiven && cd.queries == 2:
+		kind = "WHOIS query"
+	default:
+		kind = "referral WHOIS query"
+	}
+
+	return fmt.Sprintf("%s (%s)", kind, strings.ToLower(server))
+}
+
+// contextConn is a connection whose read and write deadlines are limited to
+// the context deadline.
+type contextConn struct {
+	net.Conn
+
+	// ctx is the context the connection is bound to.
+	ctx context.Context
+
+	// stop releases the function used to close the connection when the
+	// context is cancelled.
+	stop func() bool
+}
+
+// SetReadDeadline sets the read deadline, limited to the context deadline.
+func (cc *contextConn) SetReadDeadline(t time.Time) error {
+	return cc.Conn.SetReadDeadline(cc.limit(t))
+}
+
+// SetWriteDeadline sets the write deadline, limited to the context
+// deadline.
+func (cc *contextConn) SetWriteDeadline(t time.Time) error {
+	return cc.Conn.SetWriteDeadline(cc.limit(t))
+}
+
+// SetDeadline sets the read and write deadlines, limited to the context
+// deadline.
+func (cc *contextConn) SetDeadline(t time.Time) error {
+	return cc.Conn.SetDeadline(cc.limit(t))
+}
+
+// Close releases the context cancellation hook and closes the connection.
+func (cc *contextConn) Close() error {
+	cc.stop()
+
+	return cc.Conn.Close()
+}
+
+// limit provides the earlier of the given time and the context deadline.
+func (cc *contextConn) limit(t time.Time) time.Time {
+	deadline, ok := cc.ctx.Deadline()
+	if ok && (t.IsZero() || deadline.Before(t)) {
+		return deadline
+	}
+
+	return t
+}
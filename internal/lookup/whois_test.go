@@ -0,0 +1,150 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-whois
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package lookup
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/atc0005/check-whois/internal/whoistest"
+)
+
+// TestWHOISFetchDeadlineReportsStep asserts that a WHOIS lookup is cut short
+// by the context deadline and that the query in progress is reported.
+func TestWHOISFetchDeadlineReportsStep(t *testing.T) {
+	t.Parallel()
+
+	stalled := whoistest.NewServer(t)
+	stalled.Handle("example.com", whoistest.Response{Delay: 5 * time.Second})
+
+	registry := whoistest.NewServer(t)
+	registry.Handle("example.com", whoistest.Response{
+		Body: "Domain Name: example.com\r\n" +
+			whoistest.Referral("localhost:"+stalled.Port()),
+	})
+
+	tests := map[string]struct {
+		server   string
+		wantStep string
+	}{
+		"registry query": {server: stalled.Addr, wantStep: "WHOIS query (127.0.0.1)"},
+		"referral query": {server: registry.Addr, wantStep: "referral WHOIS query (localhost)"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 250*time.Millisecond)
+			defer cancel()
+
+			start := time.Now()
+			_, err := NewWHOIS(tt.server, false).Fetch(ctx, "example.com")
+
+			if elapsed := time.Since(start); elapsed > 2*time.Second {
+				t.Errorf("lookup not cut short by deadline; took %v", elapsed)
+			}
+
+			if !errors.Is(err, context.DeadlineExceeded) {
+				t.Fatalf("want deadline exceeded error, got %v", err)
+			}
+
+			if got := Step(err); got != tt.wantStep {
+				t.Errorf("\nwant step %q\ngot %q", tt.wantStep, got)
+			}
+		})
+	}
+}
+
+// TestWHOISFetchReferralHops asserts that the number of referral queries
+// made to retrieve WHOIS data is recorded.
+func TestWHOISFetchReferralHops(t *testing.T) {
+	t.Parallel()
+
+	registrar := whoistest.NewServer(t)
+	registrar.Handle("example.com", whoistest.Response{
+		Body: "Domain Name: example.com\r\nRegistrar: Example Registrar\r\n",
+	})
+
+	registry := whoistest.NewServer(t)
+	registry.Handle("example.com", whoistest.Response{
+		Body: "Domain Name: example.com\r\n" +
+			whoistest.Referral("localhost:"+registrar.Port()),
+	})
+
+	tests := map[string]struct {
+		disableReferral bool
+		want            int
+	}{
+		"referral lookups":          {want: 1},
+		"referral lookups disabled": {disableReferral: true, want: 0},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			raw, err := NewWHOIS(registry.Addr, tt.disableReferral).Fetch(ctx, "example.com")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if raw.ReferralHops != tt.want {
+				t.Errorf("want %d referral hops, got %d", tt.want, raw.ReferralHops)
+			}
+		})
+	}
+}
+
+// TestRetryStopsAtDeadline asserts that failed lookups are retried until
+// the context deadline is reached.
+func TestRetryStopsAtDeadline(t *testing.T) {
+	t.Parallel()
+
+	next := &countingLookup{err: errors.New("connection reset")}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 250*time.Millisecond)
+	defer cancel()
+
+	_, err := NewRetry(next, 100, 100*time.Millisecond).Fetch(ctx, "example.com")
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("want deadline exceeded error, got %v", err)
+	}
+
+	if next.fetches < 2 || next.fetches > 4 {
+		t.Errorf("want 2-4 fetches before the deadline, got %d", next.fetches)
+	}
+
+	if !strings.HasPrefix(Step(err), "waiting to retry lookup") {
+		t.Errorf("unexpected step %q", Step(err))
+	}
+}
+
+// TestParseMalformedWHOISData asserts that malformed WHOIS data which the
+// WHOIS parser fails to handle is reported as an error instead of a panic.
+func TestParseMalformedWHOISData(t *testing.T) {
+	t.Parallel()
+
+	raw := RawResult{
+		Domain: "example.fr",
+		Format: FormatWHOIS,
+		Data:   "domAin0.fr 00000000000000\n\nregistrar",
+	}
+
+	_, err := Parse(raw)
+	if !errors.Is(err, ErrMalformedData) {
+		t.Fatalf("want malformed data error, got %v", err)
+	}
+}
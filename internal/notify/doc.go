@@ -0,0 +1,12 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-whois
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+// Package notify provides support for tracking domain state transitions
+// (e.g., OK to WARNING or CRITICAL to OK) and sending notifications of those
+// transitions to a webhook using Microsoft Teams, Slack or plain JSON
+// payloads.
+package notify
@@ -0,0 +1,59 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-whois
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Notifier sends notifications to a webhook when the state of a domain
+// changes.
+type Notifier struct {
+	tracker *Tracker
+	webhook *Webhook
+}
+
+// New creates a new Notifier which uses the given Tracker to detect state
+// transitions and the given Webhook to deliver notifications.
+func New(tracker *Tracker, webhook *Webhook) *Notifier {
+	return &Notifier{
+		tracker: tracker,
+		webhook: webhook,
+	}
+}
+
+// Observe records the given state of the named domain, sending a
+// notification if the state has changed since the last notification. The
+// transition is only recorded if the notification is delivered, allowing
+// failed notifications to be sent again when the domain is next observed.
+//
+// The transition is returned along with true if a notification was sent.
+func (n *Notifier) Observe(ctx context.Context, name string, state string, summary string, now time.Time) (Transition, bool, error) {
+	transition, ok := n.tracker.Begin(name, state, summary, now)
+	if !ok {
+		return Transition{}, false, nil
+	}
+
+	sendErr := n.webhook.Send(ctx, transition)
+	if sendErr != nil {
+		sendErr = fmt.Errorf(
+			"failed to send notification for %s domain: %w",
+			name,
+			sendErr,
+		)
+	}
+
+	if err := n.tracker.Finish(transition, sendErr == nil); err != nil {
+		return transition, sendErr == nil, errors.Join(sendErr, err)
+	}
+
+	return transition, sendErr == nil, sendErr
+}
@@ -0,0 +1,347 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-whois
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/atc0005/go-nagios"
+)
+
+// stubWebhook is a local stand-in for a webhook endpoint, recording each
+// request body and responding with the queued status codes (or 200 OK once
+// the queue is empty).
+type stubWebhook struct {
+	mu       sync.Mutex
+	statuses []int
+	bodies   [][]byte
+}
+
+// ServeHTTP records the request body and responds with the next status.
+func (sw *stubWebhook) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+
+	sw.mu.Lock()
+	defer sw.mu.Unlock()
+
+	sw.bodies = append(sw.bodies, body)
+
+	status := http.StatusOK
+	if len(sw.statuses) > 0 {
+		status, sw.statuses = sw.statuses[0], sw.statuses[1:]
+	}
+
+	w.WriteHeader(status)
+}
+
+// requests provides the number of requests received.
+func (sw *stubWebhook) requests() int {
+	sw.mu.Lock()
+	defer sw.mu.Unlock()
+
+	return len(sw.bodies)
+}
+
+// newStubWebhook provides a webhook stand-in responding with the given
+// status codes along with a Webhook which sends JSON payloads to it.
+func newStubWebhook(t *testing.T, statuses ...int) (*stubWebhook, *Webhook) {
+	t.Helper()
+
+	stub := &stubWebhook{statuses: statuses}
+
+	ts := httptest.NewServer(stub)
+	t.Cleanup(ts.Close)
+
+	return stub, &Webhook{
+		URL:        ts.URL,
+		Format:     FormatJSON,
+		Footer:     "Notification generated by check-whois",
+		Retries:    2,
+		RetryDelay: time.Millisecond,
+		Client:     ts.Client(),
+	}
+}
+
+// TestObserveTransitions asserts that notifications are sent for state
+// transitions (including recovery) and are not repeated while the state is
+// unchanged.
+func TestObserveTransitions(t *testing.T) {
+	t.Parallel()
+
+	stub, webhook := newStubWebhook(t)
+
+	tracker, err := NewTracker("")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	notifier := New(tracker, webhook)
+
+	steps := []struct {
+		state        string
+		wantSent     bool
+		wantPrevious string
+	}{
+		{state: nagios.StateOKLabel},
+		{state: nagios.StateWARNINGLabel, wantSent: true, wantPrevious: nagios.StateOKLabel},
+		{state: nagios.StateWARNINGLabel},
+		{state: nagios.StateUNKNOWNLabel},
+		{state: nagios.StateCRITICALLabel, wantSent: true, wantPrevious: nagios.StateWARNINGLabel},
+		{state: nagios.StateCRITICALLabel},
+		{state: nagios.StateOKLabel, wantSent: true, wantPrevious: nagios.StateCRITICALLabel},
+	}
+
+	var wantRequests int
+	for i, step := range steps {
+		transition, sent, err := notifier.Observe(context.Background(), "example.com", step.state, "summary", time.Now())
+		if err != nil {
+			t.Fatalf("step %d: unexpected error: %v", i, err)
+		}
+
+		if sent != step.wantSent {
+			t.Fatalf("step %d (%s): want sent %t, got %t", i, step.state, step.wantSent, sent)
+		}
+
+		if !sent {
+			continue
+		}
+
+		wantRequests++
+
+		if transition.Previous != step.wantPrevious || transition.Current != step.state {
+			t.Errorf("step %d: unexpected transition: %+v", i, transition)
+		}
+
+		var payload jsonPayload
+		if err := json.Unmarshal(stub.bodies[len(stub.bodies)-1], &payload); err != nil {
+			t.Fatal(err)
+		}
+
+		if payload.Domain != "example.com" || payload.PreviousState != step.wantPrevious ||
+			payload.State != step.state || payload.Recovery != (step.state == nagios.StateOKLabel) ||
+			payload.Footer != webhook.Footer {
+			t.Errorf("step %d: unexpected payload: %+v", i, payload)
+		}
+	}
+
+	if got := stub.requests(); got != wantRequests {
+		t.Errorf("want %d requests, got %d", wantRequests, got)
+	}
+}
+
+// TestObserveFailedDelivery asserts that a transition is notified again
+// when the earlier notification could not be delivered.
+func TestObserveFailedDelivery(t *testing.T) {
+	t.Parallel()
+
+	stub, webhook := newStubWebhook(t, http.StatusBadRequest)
+
+	tracker, err := NewTracker("")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	notifier := New(tracker, webhook)
+
+	if _, sent, err := notifier.Observe(context.Background(), "example.com", nagios.StateWARNINGLabel, "", time.Now()); !errors.Is(err, ErrDeliveryFailed) || sent {
+		t.Fatalf("want failed delivery, got sent %t, error %v", sent, err)
+	}
+
+	if got := tracker.State("example.com"); got != "" {
+		t.Errorf("want no recorded state, got %s", got)
+	}
+
+	if _, sent, err := notifier.Observe(context.Background(), "example.com", nagios.StateWARNINGLabel, "", time.Now()); err != nil || !sent {
+		t.Fatalf("want delivery, got sent %t, error %v", sent, err)
+	}
+
+	if got := stub.requests(); got != 2 {
+		t.Errorf("want 2 requests, got %d", got)
+	}
+}
+
+// TestSendRetries asserts that server errors are retried up to the
+// configured number of retries and that client errors are not retried.
+func TestSendRetries(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		statuses     []int
+		wantErr      bool
+		wantRequests int
+	}{
+		"success": {
+			wantRequests: 1,
+		},
+		"recovered after server errors": {
+			statuses:     []int{http.StatusServiceUnavailable, http.StatusTooManyRequests},
+			wantRequests: 3,
+		},
+		"retries exhausted": {
+			statuses:     []int{http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway},
+			wantErr:      true,
+			wantRequests: 3,
+		},
+		"client error": {
+			statuses:     []int{http.StatusNotFound},
+			wantErr:      true,
+			wantRequests: 1,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			stub, webhook := newStubWebhook(t, tt.statuses...)
+
+			err := webhook.Send(context.Background(), Transition{
+				Domain:   "example.com",
+				Previous: nagios.StateOKLabel,
+				Current:  nagios.StateWARNINGLabel,
+			})
+
+			if tt.wantErr != (err != nil) {
+				t.Errorf("want error %t, got %v", tt.wantErr, err)
+			}
+
+			if got := stub.requests(); got != tt.wantRequests {
+				t.Errorf("want %d requests, got %d", tt.wantRequests, got)
+			}
+		})
+	}
+}
+
+// TestPayload asserts that each payload format includes the transition
+// details and footer.
+func TestPayload(t *testing.T) {
+	t.Parallel()
+
+	transition := Transition{
+		Domain:   "example.com",
+		Previous: nagios.StateWARNINGLabel,
+		Current:  nagios.StateCRITICALLabel,
+		Summary:  "CRITICAL: example.com domain expires in 10 days",
+		Time:     time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC),
+	}
+
+	footer := "Notification generated by check-whois"
+
+	tests := map[string]struct {
+		format Format
+		check  func(t *testing.T, body map[string]any)
+	}{
+		"json": {
+			format: FormatJSON,
+			check: func(t *testing.T, body map[string]any) {
+				if body["state"] != "CRITICAL" || body["previous_state"] != "WARNING" ||
+					body["summary"] != transition.Summary || body["footer"] != footer ||
+					body["time"] != "2026-10-19T12:00:00Z" {
+					t.Errorf("unexpected payload: %v", body)
+				}
+			},
+		},
+		"slack": {
+			format: FormatSlack,
+			check: func(t *testing.T, body map[string]any) {
+				attachment := body["attachments"].([]any)[0].(map[string]any)
+				if attachment["title"] != "example.com domain changed from WARNING to CRITICAL" ||
+					attachment["text"] != transition.Summary || attachment["footer"] != footer ||
+					attachment["color"] != "#A30200" {
+					t.Errorf("unexpected attachment: %v", attachment)
+				}
+			},
+		},
+		"teams": {
+			format: FormatTeams,
+			check: func(t *testing.T, body map[string]any) {
+				sections := body["sections"].([]any)
+				if body["@type"] != "MessageCard" || body["themeColor"] != "A30200" ||
+					body["text"] != transition.Summary || len(sections) != 2 ||
+					sections[1].(map[string]any)["text"] != footer {
+					t.Errorf("unexpected payload: %v", body)
+				}
+			},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			data, err := Payload(tt.format, transition, footer)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var body map[string]any
+			if err := json.Unmarshal(data, &body); err != nil {
+				t.Fatal(err)
+			}
+
+			tt.check(t, body)
+		})
+	}
+}
+
+// TestTrackerStateFile asserts that notified states are persisted to and
+// loaded from the state file.
+func TestTrackerStateFile(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "state.json")
+
+	tracker, err := NewTracker(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	transition, ok := tracker.Begin("example.com", nagios.StateCRITICALLabel, "", time.Now())
+	if !ok {
+		t.Fatal("want transition")
+	}
+
+	if err := tracker.Finish(transition, true); err != nil {
+		t.Fatal(err)
+	}
+
+	reloaded, err := NewTracker(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := reloaded.State("example.com"); got != nagios.StateCRITICALLabel {
+		t.Errorf("want state %s, got %q", nagios.StateCRITICALLabel, got)
+	}
+
+	if _, ok := reloaded.Begin("example.com", nagios.StateCRITICALLabel, "", time.Now()); ok {
+		t.Error("want no transition for unchanged state")
+	}
+}
+
+// TestParseFormat asserts that supported formats are accepted.
+func TestParseFormat(t *testing.T) {
+	t.Parallel()
+
+	if got, err := ParseFormat(" Teams "); err != nil || got != FormatTeams {
+		t.Errorf("want %s, got %s (%v)", FormatTeams, got, err)
+	}
+
+	if _, err := ParseFormat("discord"); !errors.Is(err, ErrUnsupportedFormat) {
+		t.Errorf("want ErrUnsupportedFormat, got %v", err)
+	}
+}
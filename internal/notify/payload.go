@@ -0,0 +1,171 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-whois
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package notify
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/atc0005/go-nagios"
+)
+
+// jsonPayload is the plain JSON notification payload.
+type jsonPayload struct {
+	Domain        string    `json:"domain"`
+	PreviousState string    `json:"previous_state"`
+	State         string    `json:"state"`
+	Recovery      bool      `json:"recovery"`
+	Summary       string    `json:"summary"`
+	Time          time.Time `json:"time"`
+	Footer        string    `json:"footer,omitempty"`
+}
+
+// slackPayload is the Slack incoming webhook notification payload.
+type slackPayload struct {
+	Text        string            `json:"text"`
+	Attachments []slackAttachment `json:"attachments"`
+}
+
+// slackAttachment is a Slack message attachment.
+type slackAttachment struct {
+	Color    string `json:"color"`
+	Title    string `json:"title"`
+	Text     string `json:"text"`
+	Footer   string `json:"footer,omitempty"`
+	Fallback string `json:"fallback"`
+	TS       int64  `json:"ts"`
+}
+
+// teamsPayload is the Microsoft Teams incoming webhook (MessageCard)
+// notification payload.
+type teamsPayload struct {
+	Type       string         `json:"@type"`
+	Context    string         `json:"@context"`
+	Summary    string         `json:"summary"`
+	ThemeColor string         `json:"themeColor"`
+	Title      string         `json:"title"`
+	Text       string         `json:"text"`
+	Sections   []teamsSection `json:"sections"`
+}
+
+// teamsSection is a Microsoft Teams MessageCard section.
+type teamsSection struct {
+	Facts []teamsFact `json:"facts,omitempty"`
+	Text  string      `json:"text,omitempty"`
+}
+
+// teamsFact is a name/value pair in a Microsoft Teams MessageCard section.
+type teamsFact struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// Payload provides the notification payload for the given transition in the
+// given format, including the given footer text if not empty.
+func Payload(format Format, transition Transition, footer string) ([]byte, error) {
+	var payload any
+
+	switch format {
+	case FormatJSON:
+		payload = jsonPayload{
+			Domain:        transition.Domain,
+			PreviousState: transition.Previous,
+			State:         transition.Current,
+			Recovery:      transition.IsRecovery(),
+			Summary:       transition.Summary,
+			Time:          transition.Time,
+			Footer:        footer,
+		}
+
+	case FormatSlack:
+		payload = slackPayload{
+			Text: Title(transition),
+			Attachments: []slackAttachment{
+				{
+					Color:    "#" + color(transition.Current),
+					Title:    Title(transition),
+					Text:     transition.Summary,
+					Footer:   footer,
+					Fallback: transition.Summary,
+					TS:       transition.Time.Unix(),
+				},
+			},
+		}
+
+	case FormatTeams:
+		sections := []teamsSection{
+			{
+				Facts: []teamsFact{
+					{Name: "Domain", Value: transition.Domain},
+					{Name: "Previous state", Value: transition.Previous},
+					{Name: "State", Value: transition.Current},
+					{Name: "Observed", Value: transition.Time.Format(time.RFC3339)},
+				},
+			},
+		}
+
+		if footer != "" {
+			sections = append(sections, teamsSection{Text: footer})
+		}
+
+		payload = teamsPayload{
+			Type:       "MessageCard",
+			Context:    "https://schema.org/extensions",
+			Summary:    Title(transition),
+			ThemeColor: color(transition.Current),
+			Title:      Title(transition),
+			Text:       transition.Summary,
+			Sections:   sections,
+		}
+
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedFormat, format)
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s notification payload: %w", format, err)
+	}
+
+	return body, nil
+}
+
+// Title provides a short description of the given transition.
+func Title(transition Transition) string {
+	if transition.IsRecovery() {
+		return fmt.Sprintf(
+			"%s domain recovered (%s to %s)",
+			transition.Domain,
+			transition.Previous,
+			transition.Current,
+		)
+	}
+
+	return fmt.Sprintf(
+		"%s domain changed from %s to %s",
+		transition.Domain,
+		transition.Previous,
+		transition.Current,
+	)
+}
+
+// color provides the hex color code used to highlight notifications for the
+// given state.
+func color(state string) string {
+	switch state {
+	case nagios.StateOKLabel:
+		return "2EB886"
+	case nagios.StateWARNINGLabel:
+		return "DAA038"
+	case nagios.StateCRITICALLabel:
+		return "A30200"
+	default:
+		return "808080"
+	}
+}
@@ -0,0 +1,210 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-whois
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package notify
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/atc0005/go-nagios"
+)
+
+// Transition is a change in the state of a domain.
+type Transition struct {
+
+	// Domain is the name of the domain.
+	Domain string
+
+	// Previous is the state label of the domain before the transition.
+	Previous string
+
+	// Current is the state label of the domain after the transition.
+	Current string
+
+	// Summary is the one-line summary of the check results which triggered
+	// the transition.
+	Summary string
+
+	// Time is when the transition was observed.
+	Time time.Time
+}
+
+// IsRecovery indicates whether the transition is a recovery to an OK
+// state.
+func (t Transition) IsRecovery() bool {
+	return t.Current == nagios.StateOKLabel
+}
+
+// trackedState is the last notified state of a domain.
+type trackedState struct {
+	State string    `json:"state"`
+	Since time.Time `json:"since"`
+}
+
+// Tracker records the last notified state of each domain in order to
+// detect state transitions. Each transition is reported once; a transition
+// which is not marked as delivered is reported again the next time the
+// domain is observed in the new state.
+//
+// Domains not previously observed are treated as being in an OK state.
+// UNKNOWN states (e.g., failed lookups) are ignored so that transient lookup
+// failures do not trigger notifications.
+type Tracker struct {
+	mu sync.Mutex
+
+	// path is the optional file used to persist the last notified states.
+	path string
+
+	// states is the last notified state of each domain.
+	states map[string]trackedState
+
+	// pending is the state of each domain with a transition being
+	// delivered.
+	pending map[string]string
+}
+
+// NewTracker creates a new Tracker. If a file path is given, the last
+// notified states are loaded from (and saved to) the file so that
+// transitions are tracked across executions. A missing file is treated as
+// an empty set of states.
+func NewTracker(path string) (*Tracker, error) {
+	t := &Tracker{
+		path:    path,
+		states:  make(map[string]trackedState),
+		pending: make(map[string]string),
+	}
+
+	if path == "" {
+		return t, nil
+	}
+
+	data, err := os.ReadFile(filepath.Clean(path))
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		return t, nil
+	case err != nil:
+		return nil, fmt.Errorf("failed to read notification state file: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &t.states); err != nil {
+		return nil, fmt.Errorf("failed to parse notification state file: %w", err)
+	}
+
+	return t, nil
+}
+
+// Begin compares the given state of the named domain against the last
+// notified state, providing the transition to be delivered if the state has
+// changed. False is returned if the state has not changed, if the state is
+// ignored or if the same transition is already being delivered.
+//
+// Each reported transition must be followed by a call to Finish.
+func (t *Tracker) Begin(name string, state string, summary string, now time.Time) (Transition, bool) {
+	if state == nagios.StateUNKNOWNLabel || state == "" {
+		return Transition{}, false
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	previous := nagios.StateOKLabel
+	if tracked, ok := t.states[name]; ok {
+		previous = tracked.State
+	}
+
+	if state == previous {
+		return Transition{}, false
+	}
+
+	if _, ok := t.pending[name]; ok {
+		return Transition{}, false
+	}
+
+	t.pending[name] = state
+
+	return Transition{
+		Domain:   name,
+		Previous: previous,
+		Current:  state,
+		Summary:  summary,
+		Time:     now,
+	}, true
+}
+
+// Finish completes the delivery of the given transition. If delivered, the
+// new state is recorded (and saved if a file is used) so that the
+// transition is not reported again.
+func (t *Tracker) Finish(transition Transition, delivered bool) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	delete(t.pending, transition.Domain)
+
+	if !delivered {
+		return nil
+	}
+
+	t.states[transition.Domain] = trackedState{
+		State: transition.Current,
+		Since: transition.Time,
+	}
+
+	return t.save()
+}
+
+// State provides the last notified state of the named domain. An empty
+// string is returned if the domain has not been observed.
+func (t *Tracker) State(name string) string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.states[name].State
+}
+
+// save writes the last notified states to the file (if used), replacing the
+// file atomically.
+func (t *Tracker) save() error {
+	if t.path == "" {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(t.states, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode notification states: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(t.path), filepath.Base(t.path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary notification state file: %w", err)
+	}
+
+	// Remove the temporary file if it is not renamed.
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+
+		return fmt.Errorf("failed to write notification state file: %w", err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to close temporary notification state file: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), t.path); err != nil {
+		return fmt.Errorf("failed to replace notification state file: %w", err)
+	}
+
+	return nil
+}
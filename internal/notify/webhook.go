@@ -0,0 +1,163 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-whois
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package notify
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Format is the payload format used to send notifications to a webhook.
+type Format string
+
+// Supported webhook payload formats.
+const (
+	FormatJSON  Format = "json"
+	FormatTeams Format = "teams"
+	FormatSlack Format = "slack"
+)
+
+// ErrUnsupportedFormat indicates that an unsupported webhook payload format
+// was requested.
+var ErrUnsupportedFormat = errors.New("unsupported webhook format")
+
+// ErrDeliveryFailed indicates that a webhook rejected a notification.
+var ErrDeliveryFailed = errors.New("webhook notification delivery failed")
+
+// SupportedFormats provides the list of supported webhook payload formats.
+func SupportedFormats() []string {
+	return []string{
+		string(FormatJSON),
+		string(FormatTeams),
+		string(FormatSlack),
+	}
+}
+
+// ParseFormat converts the given value into a supported webhook payload
+// format.
+func ParseFormat(value string) (Format, error) {
+	format := Format(strings.ToLower(strings.TrimSpace(value)))
+
+	switch format {
+	case FormatJSON, FormatTeams, FormatSlack:
+		return format, nil
+	default:
+		return "", fmt.Errorf(
+			"%w: %q; supported formats: %s",
+			ErrUnsupportedFormat,
+			value,
+			strings.Join(SupportedFormats(), ", "),
+		)
+	}
+}
+
+// Webhook sends transition notifications to a webhook URL.
+type Webhook struct {
+
+	// URL is the webhook URL notifications are sent to.
+	URL string
+
+	// Format is the payload format used for notifications.
+	Format Format
+
+	// Footer is the text included at the end of each notification (e.g.,
+	// application branding).
+	Footer string
+
+	// Retries is the number of times a failed delivery is retried.
+	Retries int
+
+	// RetryDelay is the time waited before retrying a failed delivery.
+	RetryDelay time.Duration
+
+	// Client is the HTTP client used to send notifications. The default
+	// client is used if not set.
+	Client *http.Client
+}
+
+// Send delivers a notification of the given transition to the webhook,
+// retrying failed deliveries. Network errors along with rate limiting and
+// server error responses are retried; other error responses are not.
+// Retries stop once the context is done.
+func (w *Webhook) Send(ctx context.Context, transition Transition) error {
+	body, err := Payload(w.Format, transition, w.Footer)
+	if err != nil {
+		return err
+	}
+
+	var retry bool
+	for attempt := 0; attempt <= w.Retries; attempt++ {
+		if attempt > 0 {
+			timer := time.NewTimer(w.RetryDelay)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return fmt.Errorf(
+					"waiting to retry notification (attempt %d of %d): %w",
+					attempt+1,
+					w.Retries+1,
+					errors.Join(ctx.Err(), err),
+				)
+			case <-timer.C:
+			}
+		}
+
+		retry, err = w.post(ctx, body)
+		if err == nil || !retry || ctx.Err() != nil {
+			return err
+		}
+	}
+
+	return fmt.Errorf(
+		"notification failed after %d attempts: %w",
+		w.Retries+1,
+		err,
+	)
+}
+
+// post sends the given payload to the webhook, indicating whether a failed
+// delivery should be retried.
+func (w *Webhook) post(ctx context.Context, body []byte) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, fmt.Errorf("failed to prepare notification request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	client := w.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return true, fmt.Errorf("failed to send notification: %w", err)
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	// Drain (a limited amount of) the response body to allow connection
+	// reuse.
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusMultipleChoices {
+		return false, nil
+	}
+
+	retry := resp.StatusCode == http.StatusTooManyRequests ||
+		resp.StatusCode >= http.StatusInternalServerError
+
+	return retry, fmt.Errorf("%w: %s", ErrDeliveryFailed, resp.Status)
+}
@@ -0,0 +1,14 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-whois
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+// Package output provides types and functions used to represent domain
+// evaluation results in the form emitted by the check_whois plugin for use
+// by applications other than Nagios, including the native check result
+// formats of Checkmk (local checks), Sensu Go (events), Icinga 2 (API
+// process-check-result payloads) and Nagios (passive check result external
+// commands).
+package output
@@ -0,0 +1,386 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-whois
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package output
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Format is a monitoring system native output format.
+type Format string
+
+// Supported monitoring system output formats.
+const (
+
+	// FormatCheckmk is the Checkmk local check format; one line per domain.
+	FormatCheckmk Format = "checkmk"
+
+	// FormatSensu is the Sensu Go event (check result) JSON format; one
+	// event per line.
+	FormatSensu Format = "sensu"
+
+	// FormatIcinga2 is the Icinga 2 API process-check-result action JSON
+	// format; one payload per line.
+	FormatIcinga2 Format = "icinga2"
+
+	// FormatPassive is the Nagios external command format; one
+	// PROCESS_SERVICE_CHECK_RESULT command per line.
+	FormatPassive Format = "passive"
+)
+
+// ErrUnsupportedFormat indicates that an unsupported output format was
+// requested.
+var ErrUnsupportedFormat = errors.New("unsupported output format")
+
+// SupportedFormats provides the list of supported monitoring system output
+// formats.
+func SupportedFormats() []string {
+	return []string{
+		string(FormatCheckmk),
+		string(FormatSensu),
+		string(FormatIcinga2),
+		string(FormatPassive),
+	}
+}
+
+// ParseFormat converts the given value into a supported output format.
+func ParseFormat(value string) (Format, error) {
+	format := Format(strings.ToLower(strings.TrimSpace(value)))
+
+	switch format {
+	case FormatCheckmk, FormatSensu, FormatIcinga2, FormatPassive:
+		return format, nil
+	default:
+		return "", fmt.Errorf(
+			"%w: %q; supported formats: %s",
+			ErrUnsupportedFormat,
+			value,
+			strings.Join(SupportedFormats(), ", "),
+		)
+	}
+}
+
+// String provides the performance data metric in Nagios plugin format
+// ('label'=value[UOM];[warn];[crit];[min];[max]).
+func (pd PerfData) String() string {
+	return fmt.Sprintf(
+		"'%s'=%s%s;%s;%s;%s;%s",
+		pd.Label,
+		pd.Value,
+		pd.UnitOfMeasurement,
+		pd.Warn,
+		pd.Crit,
+		pd.Min,
+		pd.Max,
+	)
+}
+
+// Write writes the given check results to the given writer in the given
+// output format using the given host and service names. Check results for
+// which a usable host or service name cannot be generated are skipped; these
+// are reported using ErrInvalidName once the other check results are
+// written.
+func Write(w io.Writer, format Format, results []Result, names Names) error {
+	var write func(io.Writer, Result, Names) error
+
+	switch format {
+	case FormatCheckmk:
+		write = writeCheckmk
+	case FormatSensu:
+		write = writeSensu
+	case FormatIcinga2:
+		write = writeIcinga2
+	case FormatPassive:
+		write = writePassive
+	default:
+		return fmt.Errorf("%w: %q", ErrUnsupportedFormat, format)
+	}
+
+	var skipped []error
+	for _, result := range results {
+		err := write(w, result, names)
+		switch {
+		case err == nil:
+		case errors.Is(err, ErrInvalidName):
+			skipped = append(skipped, fmt.Errorf("skipped %s output for %s domain: %w", format, result.Domain, err))
+		default:
+			return fmt.Errorf("failed to write %s output for %s domain: %w", format, result.Domain, err)
+		}
+	}
+
+	return errors.Join(skipped...)
+}
+
+// pluginOutput provides the check result in the form of Nagios plugin
+// output (without performance data): the one-line summary followed by the
+// report and any errors.
+func pluginOutput(result Result) string {
+	var output strings.Builder
+
+	output.WriteString(result.Summary)
+
+	if len(result.Errors) > 0 {
+		output.WriteString("\n\nErrors:\n")
+		for _, err := range result.Errors {
+			fmt.Fprintf(&output, "* %s\n", err)
+		}
+	}
+
+	if result.Report != "" {
+		output.WriteString("\n")
+		output.WriteString(strings.TrimRight(result.Report, " \n"))
+	}
+
+	return strings.TrimRight(output.String(), " \n")
+}
+
+// nagiosOutput provides the check result in the form of Nagios plugin
+// output: the one-line summary and performance data followed by the report
+// and any errors.
+func nagiosOutput(result Result) string {
+	output := pluginOutput(result)
+
+	if len(result.PerfData) == 0 {
+		return output
+	}
+
+	metrics := make([]string, 0, len(result.PerfData))
+	for _, pd := range result.PerfData {
+		metrics = append(metrics, pd.String())
+	}
+
+	summary, report, found := strings.Cut(output, "\n")
+
+	output = summary + " | " + strings.Join(metrics, " ")
+	if found {
+		output += "\n" + report
+	}
+
+	return output
+}
+
+// writeCheckmk writes the check result as a Checkmk local check line:
+//
+//	<state> "<service>" <metrics> <text>
+//
+// Metrics are listed as name=value;warn;crit;min;max separated by a pipe
+// character, or a hyphen if not available. Newlines in the text are escaped
+// as \n so that Checkmk displays them as long output.
+func writeCheckmk(w io.Writer, result Result, names Names) error {
+	service, err := names.Service(result)
+	if err != nil {
+		return err
+	}
+
+	metrics := make([]string, 0, len(result.PerfData))
+	for _, pd := range result.PerfData {
+		metrics = append(metrics, checkmkMetric(pd))
+	}
+
+	metricsField := "-"
+	if len(metrics) > 0 {
+		metricsField = strings.Join(metrics, "|")
+	}
+
+	text := strings.ReplaceAll(pluginOutput(result), "\n", `\n`)
+
+	_, err = fmt.Fprintf(
+		w,
+		"%d %s %s %s\n",
+		result.ExitCode,
+		strconv.Quote(service),
+		metricsField,
+		text,
+	)
+
+	return err
+}
+
+// checkmkMetric provides the performance data metric in Checkmk local check
+// format. Units of measurement are not supported by local checks and
+// thresholds using Nagios range syntax (e.g., 30:) have no Checkmk
+// equivalent; these are omitted as the state is already determined.
+func checkmkMetric(pd PerfData) string {
+	fields := []string{pd.Warn, pd.Crit, pd.Min, pd.Max}
+	for i, field := range fields {
+		if _, err := strconv.ParseFloat(field, 64); err != nil {
+			fields[i] = ""
+		}
+	}
+
+	return strings.TrimRight(
+		fmt.Sprintf("%s=%s;%s", pd.Label, pd.Value, strings.Join(fields, ";")),
+		";",
+	)
+}
+
+// sensuEvent is a Sensu Go event providing a check result (e.g., for
+// submission to the Sensu agent events API).
+type sensuEvent struct {
+	Check sensuCheck `json:"check"`
+}
+
+// sensuCheck is the check result of a Sensu Go event.
+type sensuCheck struct {
+	Metadata           sensuMetadata `json:"metadata"`
+	Status             int           `json:"status"`
+	Output             string        `json:"output"`
+	OutputMetricFormat string        `json:"output_metric_format,omitempty"`
+	Executed           int64         `json:"executed,omitempty"`
+}
+
+// sensuMetadata is the metadata of a Sensu Go resource.
+type sensuMetadata struct {
+	Name   string            `json:"name"`
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// sensuInvalidNameChars matches characters not permitted in Sensu resource
+// names.
+var sensuInvalidNameChars = regexp.MustCompile(`[^\w.\-]+`)
+
+// writeSensu writes the check result as a Sensu Go event on a single line.
+// The check output uses Nagios plugin format (including performance data)
+// so that Sensu extracts the metrics.
+func writeSensu(w io.Writer, result Result, names Names) error {
+	service, err := names.Service(result)
+	if err != nil {
+		return err
+	}
+
+	check := sensuCheck{
+		Metadata: sensuMetadata{
+			Name:   sensuInvalidNameChars.ReplaceAllString(service, "_"),
+			Labels: map[string]string{"domain": result.Domain},
+		},
+		Status: result.ExitCode,
+		Output: nagiosOutput(result),
+	}
+
+	if !result.CheckedAt.IsZero() {
+		check.Executed = result.CheckedAt.Unix()
+	}
+
+	if len(result.PerfData) > 0 {
+		check.OutputMetricFormat = "nagios_perfdata"
+	}
+
+	return writeJSONLine(w, sensuEvent{Check: check})
+}
+
+// icinga2CheckResult is an Icinga 2 API process-check-result action
+// payload.
+type icinga2CheckResult struct {
+	Type            string            `json:"type"`
+	Filter          string            `json:"filter"`
+	FilterVars      map[string]string `json:"filter_vars"`
+	ExitStatus      int               `json:"exit_status"`
+	PluginOutput    string            `json:"plugin_output"`
+	PerformanceData []string          `json:"performance_data,omitempty"`
+	ExecutionStart  int64             `json:"execution_start,omitempty"`
+	ExecutionEnd    int64             `json:"execution_end,omitempty"`
+}
+
+// writeIcinga2 writes the check result as an Icinga 2 API
+// process-check-result payload on a single line (e.g., for submission to
+// /v1/actions/process-check-result).
+func writeIcinga2(w io.Writer, result Result, names Names) error {
+	host, err := names.Host(result)
+	if err != nil {
+		return err
+	}
+
+	service, err := names.Service(result)
+	if err != nil {
+		return err
+	}
+
+	payload := icinga2CheckResult{
+		Type:   "Service",
+		Filter: "host.name == host_name && service.name == service_name",
+		FilterVars: map[string]string{
+			"host_name":    host,
+			"service_name": service,
+		},
+		ExitStatus:   result.ExitCode,
+		PluginOutput: pluginOutput(result),
+	}
+
+	for _, pd := range result.PerfData {
+		payload.PerformanceData = append(payload.PerformanceData, pd.String())
+	}
+
+	if !result.CheckedAt.IsZero() {
+		payload.ExecutionStart = result.CheckedAt.Unix()
+		payload.ExecutionEnd = result.CheckedAt.Unix()
+	}
+
+	return writeJSONLine(w, payload)
+}
+
+// writePassive writes the check result as a Nagios external command
+// submitting a passive service check result:
+//
+//	[<time>] PROCESS_SERVICE_CHECK_RESULT;<host>;<service>;<code>;<output>
+//
+// Newlines in the plugin output (including the report) are escaped as \n,
+// which Nagios interprets as long output. Each command is written using a
+// single write. Writes to a named pipe such as the Nagios command file are
+// only guaranteed not to be interleaved with those of other writers up to
+// PIPE_BUF bytes (4096 bytes on Linux); longer commands (e.g., those which
+// include a verbose report) may be interleaved.
+func writePassive(w io.Writer, result Result, names Names) error {
+	host, err := names.Host(result)
+	if err != nil {
+		return err
+	}
+
+	service, err := names.Service(result)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range []string{host, service} {
+		if strings.ContainsAny(name, ";\n") {
+			return fmt.Errorf("%w %q for external command", ErrInvalidName, name)
+		}
+	}
+
+	checkedAt := result.CheckedAt
+	if checkedAt.IsZero() {
+		checkedAt = time.Now()
+	}
+
+	command := fmt.Sprintf(
+		"[%d] PROCESS_SERVICE_CHECK_RESULT;%s;%s;%d;%s\n",
+		checkedAt.Unix(),
+		host,
+		service,
+		result.ExitCode,
+		strings.ReplaceAll(nagiosOutput(result), "\n", `\n`),
+	)
+
+	_, err = io.WriteString(w, command)
+
+	return err
+}
+
+// writeJSONLine writes the given value as JSON on a single line.
+func writeJSONLine(w io.Writer, value any) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+
+	return encoder.Encode(value)
+}
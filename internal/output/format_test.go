@@ -0,0 +1,206 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-whois
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testResults provides an evaluated and a failed check result.
+func testResults() []Result {
+	checkedAt := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+	return []Result{
+		{
+			Domain:   "example.com",
+			State:    "WARNING",
+			ExitCode: 1,
+			Summary:  `WARNING: "example.com" domain registration has 20d 4h remaining`,
+			Report:   "WHOIS metadata for \"example.com\" domain: \n \n* Status: ok \n",
+			PerfData: []PerfData{
+				{Label: "expires", Value: "20.17", UnitOfMeasurement: "d", Warn: "30:", Crit: "15:"},
+				{Label: "lock_coverage", Value: "16", UnitOfMeasurement: "%", Min: "0", Max: "100"},
+			},
+			CheckedAt: checkedAt,
+		},
+		{
+			Domain:    "missing.example",
+			State:     "UNKNOWN",
+			ExitCode:  3,
+			Summary:   "UNKNOWN: Error fetching WHOIS data for missing.example domain",
+			Errors:    []string{"lookup failed"},
+			CheckedAt: checkedAt,
+		},
+	}
+}
+
+// TestWriteCheckmk asserts that one Checkmk local check line is written per
+// domain.
+func TestWriteCheckmk(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	if err := Write(&buf, FormatCheckmk, testResults(), Names{}); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `1 "WHOIS example.com" expires=20.17|lock_coverage=16;;;0;100 ` +
+		`WARNING: "example.com" domain registration has 20d 4h remaining\nWHOIS metadata for "example.com" domain: \n \n* Status: ok` + "\n" +
+		`3 "WHOIS missing.example" - UNKNOWN: Error fetching WHOIS data for missing.example domain\n\nErrors:\n* lookup failed` + "\n"
+
+	if got := buf.String(); got != want {
+		t.Errorf("want:\n%s\ngot:\n%s", want, got)
+	}
+}
+
+// TestWriteSensu asserts that one Sensu event is written per domain with
+// Nagios performance data included in the check output.
+func TestWriteSensu(t *testing.T) {
+	t.Parallel()
+
+	names, err := ParseNames(DefaultHostNameTemplate, "whois/{{.Domain}}")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := Write(&buf, FormatSensu, testResults(), names); err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("want 2 events, got %d", len(lines))
+	}
+
+	var event sensuEvent
+	if err := json.Unmarshal([]byte(lines[0]), &event); err != nil {
+		t.Fatal(err)
+	}
+
+	if event.Check.Metadata.Name != "whois_example.com" || event.Check.Status != 1 ||
+		event.Check.OutputMetricFormat != "nagios_perfdata" || event.Check.Executed != 1792411200 {
+		t.Errorf("unexpected event: %+v", event)
+	}
+
+	wantOutput := `WARNING: "example.com" domain registration has 20d 4h remaining | ` +
+		`'expires'=20.17d;30:;15:;; 'lock_coverage'=16%;;;0;100` + "\n" +
+		"WHOIS metadata for \"example.com\" domain: \n \n* Status: ok"
+
+	if event.Check.Output != wantOutput {
+		t.Errorf("want output:\n%s\ngot:\n%s", wantOutput, event.Check.Output)
+	}
+}
+
+// TestWriteIcinga2 asserts that one process-check-result payload is written
+// per domain using the host and service name templates.
+func TestWriteIcinga2(t *testing.T) {
+	t.Parallel()
+
+	names, err := ParseNames("registrar-{{.Domain}}", "{{.Domain}} expiration")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := Write(&buf, FormatIcinga2, testResults()[1:], names); err != nil {
+		t.Fatal(err)
+	}
+
+	var payload icinga2CheckResult
+	if err := json.Unmarshal(buf.Bytes(), &payload); err != nil {
+		t.Fatal(err)
+	}
+
+	if payload.Type != "Service" || payload.ExitStatus != 3 ||
+		payload.FilterVars["host_name"] != "registrar-missing.example" ||
+		payload.FilterVars["service_name"] != "missing.example expiration" ||
+		!strings.Contains(payload.PluginOutput, "* lookup failed") ||
+		payload.PerformanceData != nil {
+		t.Errorf("unexpected payload: %+v", payload)
+	}
+
+	if strings.Contains(buf.String(), `\u0026`) {
+		t.Errorf("want unescaped filter expression, got %s", buf.String())
+	}
+}
+
+// TestWritePassive asserts that one Nagios external command is written per
+// domain with the performance data and escaped long output included.
+func TestWritePassive(t *testing.T) {
+	t.Parallel()
+
+	names, err := ParseNames("domains", "WHOIS {{.Domain}}")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := Write(&buf, FormatPassive, testResults(), names); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `[1792411200] PROCESS_SERVICE_CHECK_RESULT;domains;WHOIS example.com;1;` +
+		`WARNING: "example.com" domain registration has 20d 4h remaining | 'expires'=20.17d;30:;15:;; 'lock_coverage'=16%;;;0;100` +
+		`\nWHOIS metadata for "example.com" domain: \n \n* Status: ok` + "\n" +
+		`[1792411200] PROCESS_SERVICE_CHECK_RESULT;domains;WHOIS missing.example;3;` +
+		`UNKNOWN: Error fetching WHOIS data for missing.example domain\n\nErrors:\n* lookup failed` + "\n"
+
+	if got := buf.String(); got != want {
+		t.Errorf("want:\n%s\ngot:\n%s", want, got)
+	}
+
+	// A host name containing a field separator skips only the affected
+	// domain.
+	invalid, err := ParseNames(`{{if eq .Domain "missing.example"}}{{.Domain}};extra{{else}}domains{{end}}`, DefaultServiceNameTemplate)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	buf.Reset()
+	err = Write(&buf, FormatPassive, testResults(), invalid)
+	if !errors.Is(err, ErrInvalidName) {
+		t.Errorf("want error %v for host name containing a field separator, got %v", ErrInvalidName, err)
+	}
+
+	if got := buf.String(); !strings.HasPrefix(got, "[1792411200] PROCESS_SERVICE_CHECK_RESULT;domains;WHOIS example.com;1;") ||
+		strings.Contains(got, "missing.example") {
+		t.Errorf("want only example.com command, got:\n%s", got)
+	}
+}
+
+// TestParseNames asserts that invalid or empty name templates are reported.
+func TestParseNames(t *testing.T) {
+	t.Parallel()
+
+	if _, err := ParseNames("{{.Domain", DefaultServiceNameTemplate); err == nil {
+		t.Error("want error for malformed template")
+	}
+
+	names, err := ParseNames("{{.Missing}}", "{{if false}}x{{end}}")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := names.Host(Result{Domain: "example.com"}); err == nil {
+		t.Error("want error for unknown field")
+	}
+
+	if _, err := names.Service(Result{Domain: "example.com"}); err == nil {
+		t.Error("want error for empty name")
+	}
+
+	if _, err := ParseFormat("nagios"); !errors.Is(err, ErrUnsupportedFormat) {
+		t.Errorf("want ErrUnsupportedFormat, got %v", err)
+	}
+}
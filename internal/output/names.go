@@ -0,0 +1,89 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-whois
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package output
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// Default host and service name templates.
+const (
+	DefaultHostNameTemplate    string = "{{.Domain}}"
+	DefaultServiceNameTemplate string = "WHOIS {{.Domain}}"
+)
+
+// ErrInvalidName indicates that the host or service name generated for a
+// check result cannot be used.
+var ErrInvalidName = errors.New("invalid host or service name")
+
+// NameData is the data available to host and service name templates.
+type NameData struct {
+
+	// Domain is the name of the checked domain.
+	Domain string
+
+	// State is the service state label (e.g., WARNING) of the check result.
+	State string
+}
+
+// Names provides the monitoring system host and service names used for the
+// check result of each domain.
+type Names struct {
+	host    *template.Template
+	service *template.Template
+}
+
+// ParseNames parses the given host and service name templates. The
+// templates are Go text/template templates provided a NameData value (e.g.,
+// "WHOIS {{.Domain}}").
+func ParseNames(hostTemplate string, serviceTemplate string) (Names, error) {
+	host, err := template.New("host").Option("missingkey=error").Parse(hostTemplate)
+	if err != nil {
+		return Names{}, fmt.Errorf("failed to parse host name template: %w", err)
+	}
+
+	service, err := template.New("service").Option("missingkey=error").Parse(serviceTemplate)
+	if err != nil {
+		return Names{}, fmt.Errorf("failed to parse service name template: %w", err)
+	}
+
+	return Names{host: host, service: service}, nil
+}
+
+// Host provides the host name for the given check result.
+func (n Names) Host(result Result) (string, error) {
+	return execute("host", n.host, DefaultHostNameTemplate, result)
+}
+
+// Service provides the service name for the given check result.
+func (n Names) Service(result Result) (string, error) {
+	return execute("service", n.service, DefaultServiceNameTemplate, result)
+}
+
+// execute applies the given kind of name template (or the given default
+// template if not set) to the given check result.
+func execute(kind string, tmpl *template.Template, defaultTemplate string, result Result) (string, error) {
+	if tmpl == nil {
+		tmpl = template.Must(template.New(kind).Parse(defaultTemplate))
+	}
+
+	var name strings.Builder
+	if err := tmpl.Execute(&name, NameData{Domain: result.Domain, State: result.State}); err != nil {
+		return "", fmt.Errorf("%w: failed to generate %s name for %s domain: %w", ErrInvalidName, kind, result.Domain, err)
+	}
+
+	value := strings.TrimSpace(name.String())
+	if value == "" {
+		return "", fmt.Errorf("%w: empty %s name generated for %s domain", ErrInvalidName, kind, result.Domain)
+	}
+
+	return value, nil
+}
@@ -0,0 +1,230 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-whois
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package output
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/atc0005/check-whois/internal/checker"
+	"github.com/atc0005/check-whois/internal/domain"
+	"github.com/atc0005/check-whois/internal/lookup"
+
+	"github.com/atc0005/go-nagios"
+)
+
+// Result is the result of checking a domain, providing the same details as
+// the check_whois plugin output along with the service state.
+type Result struct {
+
+	// Domain is the name of the checked domain.
+	Domain string `json:"domain"`
+
+	// State is the service state label (e.g., WARNING).
+	State string `json:"state"`
+
+	// ExitCode is the plugin exit code for the service state.
+	ExitCode int `json:"exit_code"`
+
+	// Summary is the one-line summary of the check results (the plugin
+	// service output).
+	Summary string `json:"summary"`
+
+	// Report is the detailed report of the domain registration details (the
+	// plugin long service output).
+	Report string `json:"report,omitempty"`
+
+	// PerfData is the collection of performance data metrics for the
+	// domain.
+	PerfData []PerfData `json:"perfdata,omitempty"`
+
+	// Errors is the collection of errors and problems encountered while
+	// checking the domain.
+	Errors []string `json:"errors,omitempty"`
+
+	// ExpirationDate is the domain expiration date in the display time
+	// zone. This is omitted if the domain could not be evaluated.
+	ExpirationDate *time.Time `json:"expiration_date,omitempty"`
+
+	// DaysRemaining is the whole number of days remaining until the domain
+	// expires. This is omitted if the domain could not be evaluated.
+	DaysRemaining *int `json:"days_remaining,omitempty"`
+
+	// CheckedAt is the time the domain was checked in the display time
+	// zone.
+	CheckedAt time.Time `json:"checked_at"`
+}
+
+// PerfData is a performance data metric for a checked domain.
+type PerfData struct {
+	Label             string `json:"label"`
+	Value             string `json:"value"`
+	UnitOfMeasurement string `json:"uom,omitempty"`
+	Warn              string `json:"warn,omitempty"`
+	Crit              string `json:"crit,omitempty"`
+	Min               string `json:"min,omitempty"`
+	Max               string `json:"max,omitempty"`
+}
+
+// Options is the collection of settings used to describe check results in
+// the same way as the plugin.
+type Options struct {
+
+	// Timeout is the time allowed for the check to complete.
+	Timeout time.Duration
+
+	// TimeoutState is the service state used when the timeout is reached
+	// before the check completes.
+	TimeoutState nagios.ServiceState
+
+	// MissingExpirationState is the service state used when the domain
+	// expiration date is missing from the registration data.
+	MissingExpirationState nagios.ServiceState
+
+	// VerboseReport indicates whether the report lists every contact block.
+	VerboseReport bool
+
+	// DateFormat is the layout and time zone used to display dates. Only the
+	// time zone applies to the ExpirationDate and CheckedAt values; these
+	// are encoded using RFC 3339 so that they remain machine-readable.
+	DateFormat domain.DateFormat
+}
+
+// New creates a Result from the outcome of checking the named domain.
+func New(name string, outcome checker.Outcome, opts Options, checkedAt time.Time) Result {
+	checkedAt = opts.DateFormat.In(checkedAt)
+
+	if outcome.Err != nil {
+		return failure(name, outcome.Err, opts, checkedAt)
+	}
+
+	d := outcome.Domain
+
+	result := Result{
+		Domain:    name,
+		State:     outcome.Result.State.Label,
+		ExitCode:  outcome.Result.State.ExitCode,
+		Summary:   strings.TrimSuffix(d.OneLineCheckSummary(), nagios.CheckOutputEOL),
+		Report:    d.Report(),
+		CheckedAt: checkedAt,
+	}
+
+	if opts.VerboseReport {
+		result.Report = d.VerboseReport()
+	}
+
+	// Performance data is generated from the same domain metadata evaluated
+	// by the checker; errors are only returned for nil metadata.
+	pd, _ := domain.PerfData(d)
+	for _, metric := range pd {
+		result.PerfData = append(result.PerfData, PerfData{
+			Label:             metric.Label,
+			Value:             metric.Value,
+			UnitOfMeasurement: metric.UnitOfMeasurement,
+			Warn:              metric.Warn,
+			Crit:              metric.Crit,
+			Min:               metric.Min,
+			Max:               metric.Max,
+		})
+	}
+
+	for _, problem := range outcome.Result.Problems {
+		result.Errors = append(result.Errors, problem.Error())
+	}
+
+	expires := opts.DateFormat.In(d.ExpirationDate)
+	result.ExpirationDate = &expires
+
+	if days, err := domain.UntilExpiration(d); err == nil {
+		result.DaysRemaining = &days
+	}
+
+	return result
+}
+
+// failure creates a Result describing the error encountered while checking
+// the named domain.
+func failure(name string, err error, opts Options, checkedAt time.Time) Result {
+	state, summary := Failure(name, err, opts)
+
+	return Result{
+		Domain:    name,
+		State:     state.Label,
+		ExitCode:  state.ExitCode,
+		Summary:   summary,
+		Errors:    []string{err.Error()},
+		CheckedAt: checkedAt,
+	}
+}
+
+// Failure provides the service state and one-line summary describing the
+// error encountered while checking the named domain. The plugin and the API
+// server share this wording.
+func Failure(name string, err error, opts Options) (nagios.ServiceState, string) {
+	state := nagios.ServiceState{
+		Label:    nagios.StateUNKNOWNLabel,
+		ExitCode: nagios.StateUNKNOWNExitCode,
+	}
+
+	var summary string
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		step := lookup.Step(err)
+		if step == "" {
+			step = "registration data lookup"
+		}
+
+		state = stateOrDefault(opts.TimeoutState, state)
+		summary = fmt.Sprintf(
+			"%s: Timeout (%v) reached during %s for %s domain",
+			state.Label,
+			opts.Timeout,
+			step,
+			name,
+		)
+
+	case errors.Is(err, domain.ErrMissingExpirationDate):
+		state = stateOrDefault(opts.MissingExpirationState, state)
+		summary = fmt.Sprintf(
+			"%s: Expiration date not found in registration data for %s domain",
+			state.Label,
+			name,
+		)
+
+	default:
+		step := "parsing WhoisInfo data"
+		switch {
+		case errors.Is(err, checker.ErrFetchFailed):
+			step = "fetching WHOIS data"
+		case errors.Is(err, checker.ErrParseFailed):
+			step = "parsing WHOIS data"
+		}
+
+		summary = fmt.Sprintf(
+			"%s: Error %s for %s domain",
+			state.Label,
+			step,
+			name,
+		)
+	}
+
+	return state, summary
+}
+
+// stateOrDefault provides the given service state, or the given default
+// state if the service state is not set.
+func stateOrDefault(state nagios.ServiceState, defaultState nagios.ServiceState) nagios.ServiceState {
+	if state.Label == "" {
+		return defaultState
+	}
+
+	return state
+}
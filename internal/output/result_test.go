@@ -0,0 +1,99 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-whois
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package output
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/atc0005/check-whois/internal/checker"
+	"github.com/atc0005/check-whois/internal/domain"
+
+	"github.com/atc0005/go-nagios"
+)
+
+// TestNewFailure asserts that check errors are described in the same way
+// as the plugin using the configured states.
+func TestNewFailure(t *testing.T) {
+	t.Parallel()
+
+	opts := Options{
+		Timeout: 30 * time.Second,
+		TimeoutState: nagios.ServiceState{
+			Label:    nagios.StateCRITICALLabel,
+			ExitCode: nagios.StateCRITICALExitCode,
+		},
+		MissingExpirationState: nagios.ServiceState{
+			Label:    nagios.StateWARNINGLabel,
+			ExitCode: nagios.StateWARNINGExitCode,
+		},
+	}
+
+	tests := map[string]struct {
+		err          error
+		opts         Options
+		wantState    string
+		wantExitCode int
+		wantSummary  string
+	}{
+		"timeout": {
+			err:          fmt.Errorf("%w: %w", checker.ErrFetchFailed, context.DeadlineExceeded),
+			opts:         opts,
+			wantState:    nagios.StateCRITICALLabel,
+			wantExitCode: nagios.StateCRITICALExitCode,
+			wantSummary:  "CRITICAL: Timeout (30s) reached during registration data lookup for example.com domain",
+		},
+		"timeout default state": {
+			err:          fmt.Errorf("%w: %w", checker.ErrFetchFailed, context.DeadlineExceeded),
+			wantState:    nagios.StateUNKNOWNLabel,
+			wantExitCode: nagios.StateUNKNOWNExitCode,
+			wantSummary:  "UNKNOWN: Timeout (0s) reached during registration data lookup for example.com domain",
+		},
+		"missing expiration": {
+			err:          fmt.Errorf("%w: %w", checker.ErrEvaluateFailed, domain.ErrMissingExpirationDate),
+			opts:         opts,
+			wantState:    nagios.StateWARNINGLabel,
+			wantExitCode: nagios.StateWARNINGExitCode,
+			wantSummary:  "WARNING: Expiration date not found in registration data for example.com domain",
+		},
+		"parse": {
+			err:          fmt.Errorf("%w: %w", checker.ErrParseFailed, errors.New("malformed")),
+			opts:         opts,
+			wantState:    nagios.StateUNKNOWNLabel,
+			wantExitCode: nagios.StateUNKNOWNExitCode,
+			wantSummary:  "UNKNOWN: Error parsing WHOIS data for example.com domain",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := New("example.com", checker.Outcome{Err: tt.err}, tt.opts, time.Now())
+
+			if got.State != tt.wantState || got.ExitCode != tt.wantExitCode {
+				t.Errorf("want state %s (%d), got %s (%d)", tt.wantState, tt.wantExitCode, got.State, got.ExitCode)
+			}
+
+			if got.Summary != tt.wantSummary {
+				t.Errorf("want summary %q, got %q", tt.wantSummary, got.Summary)
+			}
+
+			if len(got.Errors) != 1 || got.Errors[0] != tt.err.Error() {
+				t.Errorf("want errors [%q], got %q", tt.err, got.Errors)
+			}
+
+			if got.ExpirationDate != nil || got.DaysRemaining != nil || got.PerfData != nil {
+				t.Errorf("want no domain details, got %+v", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-whois
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package server
+
+import (
+	"sync"
+	"time"
+
+	"github.com/atc0005/check-whois/internal/output"
+)
+
+// cacheEntry is a cached check result.
+type cacheEntry struct {
+	result  output.Result
+	expires time.Time
+}
+
+// cache holds check results for reuse until they expire. Expired entries
+// are removed when results are added.
+type cache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[string]cacheEntry
+}
+
+// newCache creates a cache which holds check results for the given time. A
+// zero duration disables caching.
+func newCache(ttl time.Duration) *cache {
+	return &cache{
+		ttl:     ttl,
+		entries: make(map[string]cacheEntry),
+	}
+}
+
+// get provides the cached check result for the given domain if one has not
+// expired at the given time.
+func (c *cache) get(name string, now time.Time) (output.Result, bool) {
+	if c.ttl <= 0 {
+		return output.Result{}, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[name]
+	if !ok || !now.Before(entry.expires) {
+		return output.Result{}, false
+	}
+
+	return entry.result, true
+}
+
+// set records the check result for the given domain checked at the given
+// time.
+func (c *cache) set(name string, result output.Result, now time.Time) {
+	if c.ttl <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key, entry := range c.entries {
+		if !now.Before(entry.expires) {
+			delete(c.entries, key)
+		}
+	}
+
+	c.entries[name] = cacheEntry{
+		result:  result,
+		expires: now.Add(c.ttl),
+	}
+}
@@ -0,0 +1,422 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-whois
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/atc0005/check-whois/internal/checker"
+	"github.com/atc0005/check-whois/internal/notify"
+	"github.com/atc0005/check-whois/internal/output"
+)
+
+// MaxBatchDomains is the maximum number of domains accepted in a single
+// batch check request.
+const MaxBatchDomains int = 100
+
+// maxRequestBytes is the maximum size of a request body.
+const maxRequestBytes int64 = 1 << 20
+
+// Cache status values provided via the cacheHeader response header for
+// single domain requests.
+const (
+	cacheHeader string = "X-Cache"
+	cacheHit    string = "HIT"
+	cacheMiss   string = "MISS"
+)
+
+// Config is the collection of settings used by the API server.
+type Config struct {
+
+	// Concurrency is the maximum number of domains checked at the same time
+	// across all requests.
+	Concurrency int
+
+	// Timeout is the time allowed to check each domain.
+	Timeout time.Duration
+
+	// CacheTTL is the time check results are reused before domains are
+	// checked again. Results are not cached if zero.
+	CacheTTL time.Duration
+
+	// Normalize converts a requested domain name into the registrable
+	// domain in ASCII (punycode) form. Names are used as-is if not set.
+	Normalize func(name string) (string, error)
+
+	// Output is the collection of settings used to describe check results
+	// in the same way as the plugin.
+	Output output.Options
+
+	// Notifier is the optional Notifier used to send webhook notifications
+	// when the state of a checked domain changes.
+	Notifier *notify.Notifier
+
+	// Log is the logger used to record requests and check failures.
+	Log zerolog.Logger
+}
+
+// BatchRequest is the request body for a batch check request.
+type BatchRequest struct {
+
+	// Domains is the collection of domain names to check.
+	Domains []string `json:"domains"`
+}
+
+// BatchResponse is the response body for a batch check request.
+type BatchResponse struct {
+
+	// Results is the collection of check results in the same order as the
+	// requested domain names.
+	Results []output.Result `json:"results"`
+}
+
+// ErrorResponse is the response body for a request which could not be
+// processed.
+type ErrorResponse struct {
+
+	// Error describes why the request could not be processed.
+	Error string `json:"error"`
+}
+
+// Server checks domains on behalf of HTTP API clients.
+type Server struct {
+
+	// checker is used to check the registration data for each domain.
+	checker *checker.Checker
+
+	// config is the collection of settings used by the API server.
+	config Config
+
+	// semaphore limits the number of domains checked at the same time.
+	semaphore chan struct{}
+
+	// cache holds recent check results.
+	cache *cache
+
+	// notifications tracks webhook notifications in progress.
+	notifications sync.WaitGroup
+}
+
+// New creates a new Server which checks domains using the given Checker.
+func New(c *checker.Checker, config Config) *Server {
+	if config.Concurrency < 1 {
+		config.Concurrency = 1
+	}
+
+	return &Server{
+		checker:   c,
+		config:    config,
+		semaphore: make(chan struct{}, config.Concurrency),
+		cache:     newCache(config.CacheTTL),
+	}
+}
+
+// Handler provides the HTTP handler for the API endpoints with request
+// logging applied.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/domains/{name}", s.handleDomain)
+	mux.HandleFunc("POST /v1/check", s.handleBatch)
+
+	return s.logRequests(mux)
+}
+
+// handleDomain checks the domain named in the request path.
+func (s *Server) handleDomain(w http.ResponseWriter, r *http.Request) {
+	name, err := s.normalize(r.PathValue("name"))
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+
+		return
+	}
+
+	result, cached := s.check(r.Context(), name)
+
+	w.Header().Set(cacheHeader, cacheMiss)
+	if cached {
+		w.Header().Set(cacheHeader, cacheHit)
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+// handleBatch checks each of the domains listed in the request body.
+func (s *Server) handleBatch(w http.ResponseWriter, r *http.Request) {
+	var request BatchRequest
+
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestBytes))
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(&request); err != nil {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{
+			Error: fmt.Sprintf("invalid request body: %v", err),
+		})
+
+		return
+	}
+
+	switch {
+	case len(request.Domains) == 0:
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{
+			Error: "no domains provided",
+		})
+
+		return
+
+	case len(request.Domains) > MaxBatchDomains:
+		writeJSON(w, http.StatusRequestEntityTooLarge, ErrorResponse{
+			Error: fmt.Sprintf(
+				"%d domains provided; a maximum of %d domains is supported",
+				len(request.Domains),
+				MaxBatchDomains,
+			),
+		})
+
+		return
+	}
+
+	names := make([]string, len(request.Domains))
+	for i, input := range request.Domains {
+		name, err := s.normalize(input)
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+
+			return
+		}
+
+		names[i] = name
+	}
+
+	results := make([]output.Result, len(names))
+
+	var wg sync.WaitGroup
+	for i, name := range names {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], _ = s.check(r.Context(), name)
+		}()
+	}
+
+	wg.Wait()
+
+	writeJSON(w, http.StatusOK, BatchResponse{Results: results})
+}
+
+// normalize converts the given requested domain name into the form used
+// for lookups.
+func (s *Server) normalize(input string) (string, error) {
+	if input == "" {
+		return "", fmt.Errorf("domain name not provided")
+	}
+
+	if s.config.Normalize == nil {
+		return input, nil
+	}
+
+	name, err := s.config.Normalize(input)
+	if err != nil {
+		return "", fmt.Errorf("invalid domain name %q: %w", input, err)
+	}
+
+	return name, nil
+}
+
+// check provides the check result for the given domain from the cache, or
+// checks the domain if a result is not cached. The number of domains
+// checked at the same time is limited to the configured concurrency.
+func (s *Server) check(ctx context.Context, name string) (output.Result, bool) {
+	if result, ok := s.cache.get(name, time.Now()); ok {
+		return result, true
+	}
+
+	select {
+	case s.semaphore <- struct{}{}:
+		defer func() { <-s.semaphore }()
+
+	case <-ctx.Done():
+		return output.New(
+			name,
+			checker.Outcome{Err: fmt.Errorf("%w: %w", checker.ErrFetchFailed, ctx.Err())},
+			s.config.Output,
+			time.Now(),
+		), false
+	}
+
+	// Another request may have checked the domain while waiting.
+	if result, ok := s.cache.get(name, time.Now()); ok {
+		return result, true
+	}
+
+	checkCtx := ctx
+	if s.config.Timeout > 0 {
+		var cancel context.CancelFunc
+		checkCtx, cancel = context.WithTimeout(ctx, s.config.Timeout)
+		defer cancel()
+	}
+
+	d, checkResult, err := s.checker.Check(checkCtx, name)
+	outcome := checker.Outcome{Domain: d, Result: checkResult, Err: err}
+
+	now := time.Now()
+	result := output.New(name, outcome, s.config.Output, now)
+
+	s.notify(result)
+
+	if err != nil {
+		s.config.Log.Error().
+			Err(err).
+			Str("domain", name).
+			Msg("failed to check domain")
+
+		return result, false
+	}
+
+	s.cache.set(name, result, now)
+
+	return result, false
+}
+
+// notify sends a webhook notification in the background if the state of the
+// checked domain has changed since the last notification. Notifications are
+// not tied to the request so that slow webhooks do not delay responses.
+func (s *Server) notify(result output.Result) {
+	if s.config.Notifier == nil {
+		return
+	}
+
+	s.notifications.Add(1)
+	go func() {
+		defer s.notifications.Done()
+
+		transition, sent, err := s.config.Notifier.Observe(
+			context.Background(),
+			result.Domain,
+			result.State,
+			result.Summary,
+			result.CheckedAt,
+		)
+
+		switch {
+		case err != nil:
+			s.config.Log.Error().
+				Err(err).
+				Str("domain", result.Domain).
+				Msg("failed to send state transition notification")
+
+		case sent:
+			s.config.Log.Info().
+				Str("domain", result.Domain).
+				Str("previous_state", transition.Previous).
+				Str("state", transition.Current).
+				Msg("sent state transition notification")
+		}
+	}()
+}
+
+// writeJSON writes the given value as the JSON response body with the given
+// HTTP status code.
+func writeJSON(w http.ResponseWriter, status int, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	// The status has already been sent; there is nothing further to report
+	// to the client if encoding fails.
+	_ = encoder.Encode(value)
+}
+
+// statusRecorder records the status code and size of a response.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+	bytes  int
+}
+
+// WriteHeader records the status code before sending it.
+func (sr *statusRecorder) WriteHeader(status int) {
+	sr.status = status
+	sr.ResponseWriter.WriteHeader(status)
+}
+
+// Write records the number of bytes written.
+func (sr *statusRecorder) Write(b []byte) (int, error) {
+	n, err := sr.ResponseWriter.Write(b)
+	sr.bytes += n
+
+	return n, err
+}
+
+// logRequests records each request handled by the given handler.
+func (s *Server) logRequests(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+
+		next.ServeHTTP(recorder, r)
+
+		event := s.config.Log.Info()
+		if recorder.status >= http.StatusInternalServerError {
+			event = s.config.Log.Error()
+		}
+
+		event.
+			Str("method", r.Method).
+			Str("path", r.URL.Path).
+			Str("remote_addr", r.RemoteAddr).
+			Int("status", recorder.status).
+			Int("bytes", recorder.bytes).
+			Dur("duration", time.Since(start)).
+			Msg("handled request")
+	})
+}
+
+// ListenAndServe listens on the given TCP network address and handles API
+// requests until the given context is done, at which point the server is
+// shut down gracefully after waiting for webhook notifications in progress.
+func (s *Server) ListenAndServe(ctx context.Context, addr string) error {
+	srv := &http.Server{
+		Addr:              addr,
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	shutdownErr := make(chan error, 1)
+	go func() {
+		<-ctx.Done()
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), s.config.Timeout)
+		defer cancel()
+
+		shutdownErr <- srv.Shutdown(shutdownCtx)
+	}()
+
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("failed to serve API requests: %w", err)
+	}
+
+	err := <-shutdownErr
+
+	s.notifications.Wait()
+
+	if err != nil {
+		return fmt.Errorf("failed to shut down API server: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,394 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-whois
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/atc0005/check-whois/internal/checker"
+	"github.com/atc0005/check-whois/internal/domain"
+	"github.com/atc0005/check-whois/internal/lookup"
+	"github.com/atc0005/check-whois/internal/notify"
+	"github.com/atc0005/check-whois/internal/output"
+
+	"github.com/atc0005/go-nagios"
+)
+
+// countingLookup provides registration data for known domains, recording
+// the number of lookups and the highest number of lookups in progress at
+// the same time.
+type countingLookup struct {
+	expiresIn map[string]time.Duration
+	delay     time.Duration
+
+	lookups  atomic.Int32
+	active   atomic.Int32
+	mu       sync.Mutex
+	maxInUse int32
+}
+
+// Fetch provides the registration data for the given domain.
+func (cl *countingLookup) Fetch(ctx context.Context, name string) (lookup.RawResult, error) {
+	cl.lookups.Add(1)
+
+	active := cl.active.Add(1)
+	defer cl.active.Add(-1)
+
+	cl.mu.Lock()
+	cl.maxInUse = max(cl.maxInUse, active)
+	cl.mu.Unlock()
+
+	select {
+	case <-time.After(cl.delay):
+	case <-ctx.Done():
+		return lookup.RawResult{}, ctx.Err()
+	}
+
+	expiresIn, ok := cl.expiresIn[name]
+	if !ok {
+		return lookup.RawResult{}, fmt.Errorf("no registration data for %s", name)
+	}
+
+	return lookup.RawResult{
+		Domain: name,
+		Format: lookup.FormatWHOIS,
+		Data: fmt.Sprintf(
+			"Domain Name: %s\n"+
+				"Registrar: Example Registrar, Inc.\n"+
+				"Registry Expiry Date: %s\n"+
+				"Domain Status: clientTransferProhibited\n",
+			name,
+			time.Now().UTC().Add(expiresIn).Format(time.RFC3339),
+		),
+	}, nil
+}
+
+// newTestServer provides an API server using the given lookup along with
+// 30 and 15 day thresholds.
+func newTestServer(t *testing.T, l lookup.Lookup, config Config) *httptest.Server {
+	t.Helper()
+
+	warning, err := domain.ParseThreshold("30")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	critical, err := domain.ParseThreshold("15")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := checker.New(l, checker.Config{
+		AgeWarning:  warning,
+		AgeCritical: critical,
+	})
+
+	config.Log = zerolog.Nop()
+	config.Normalize = func(name string) (string, error) {
+		if strings.ContainsAny(name, " /") {
+			return "", domain.ErrNotRegistrableDomain
+		}
+
+		return strings.ToLower(name), nil
+	}
+
+	ts := httptest.NewServer(New(c, config).Handler())
+	t.Cleanup(ts.Close)
+
+	return ts
+}
+
+// decode decodes the JSON response body into the given value.
+func decode(t *testing.T, resp *http.Response, value any) {
+	t.Helper()
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if got := resp.Header.Get("Content-Type"); got != "application/json" {
+		t.Errorf("want JSON content type, got %q", got)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(value); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+}
+
+// TestGetDomain asserts that a single domain is checked and that the result
+// is cached.
+func TestGetDomain(t *testing.T) {
+	t.Parallel()
+
+	l := &countingLookup{expiresIn: map[string]time.Duration{
+		"example.com": 20 * 24 * time.Hour,
+	}}
+
+	ts := newTestServer(t, l, Config{Concurrency: 2, CacheTTL: time.Hour})
+
+	for i, wantCache := range []string{cacheMiss, cacheHit} {
+		resp, err := http.Get(ts.URL + "/v1/domains/EXAMPLE.com")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("request %d: want status %d, got %d", i, http.StatusOK, resp.StatusCode)
+		}
+
+		if got := resp.Header.Get(cacheHeader); got != wantCache {
+			t.Errorf("request %d: want cache status %s, got %s", i, wantCache, got)
+		}
+
+		var result output.Result
+		decode(t, resp, &result)
+
+		if result.Domain != "example.com" || result.State != nagios.StateWARNINGLabel ||
+			result.ExitCode != nagios.StateWARNINGExitCode {
+			t.Errorf("request %d: unexpected result: %+v", i, result)
+		}
+
+		if !strings.HasPrefix(result.Summary, "WARNING: ") || !strings.Contains(result.Report, "Example Registrar, Inc.") {
+			t.Errorf("request %d: unexpected summary or report: %+v", i, result)
+		}
+
+		if result.DaysRemaining == nil || *result.DaysRemaining != 19 {
+			t.Errorf("request %d: want 19 days remaining, got %v", i, result.DaysRemaining)
+		}
+
+		if len(result.PerfData) == 0 || result.PerfData[0].Label != "expires" {
+			t.Errorf("request %d: want expires performance data, got %+v", i, result.PerfData)
+		}
+	}
+
+	if got := l.lookups.Load(); got != 1 {
+		t.Errorf("want 1 lookup, got %d", got)
+	}
+}
+
+// TestGetDomainNotify asserts that a webhook notification is sent when the
+// state of a checked domain changes.
+func TestGetDomainNotify(t *testing.T) {
+	t.Parallel()
+
+	received := make(chan map[string]any, 1)
+
+	webhook := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		var payload map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Errorf("failed to decode notification: %v", err)
+		}
+
+		received <- payload
+	}))
+	t.Cleanup(webhook.Close)
+
+	tracker, err := notify.NewTracker("")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	notifier := notify.New(tracker, &notify.Webhook{
+		URL:    webhook.URL,
+		Format: notify.FormatJSON,
+		Client: webhook.Client(),
+	})
+
+	l := &countingLookup{expiresIn: map[string]time.Duration{
+		"example.com": 10 * 24 * time.Hour,
+	}}
+
+	ts := newTestServer(t, l, Config{Notifier: notifier})
+
+	resp, err := http.Get(ts.URL + "/v1/domains/example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+
+	select {
+	case payload := <-received:
+		if payload["domain"] != "example.com" || payload["previous_state"] != nagios.StateOKLabel ||
+			payload["state"] != nagios.StateCRITICALLabel {
+			t.Errorf("unexpected notification: %v", payload)
+		}
+
+	case <-time.After(5 * time.Second):
+		t.Fatal("notification not received")
+	}
+}
+
+// TestGetDomainInvalid asserts that invalid domain names are rejected.
+func TestGetDomainInvalid(t *testing.T) {
+	t.Parallel()
+
+	ts := newTestServer(t, &countingLookup{}, Config{})
+
+	resp, err := http.Get(ts.URL + "/v1/domains/not%20valid")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("want status %d, got %d", http.StatusBadRequest, resp.StatusCode)
+	}
+
+	var errResp ErrorResponse
+	decode(t, resp, &errResp)
+
+	if !strings.Contains(errResp.Error, "invalid domain name") {
+		t.Errorf("unexpected error: %q", errResp.Error)
+	}
+}
+
+// TestBatchCheck asserts that batch results are returned in request order,
+// that failed lookups are reported with an UNKNOWN state and that the
+// number of lookups in progress is limited.
+func TestBatchCheck(t *testing.T) {
+	t.Parallel()
+
+	day := 24 * time.Hour
+
+	l := &countingLookup{
+		delay: 20 * time.Millisecond,
+		expiresIn: map[string]time.Duration{
+			"a.example": 90 * day,
+			"b.example": 20 * day,
+			"c.example": 10 * day,
+			"d.example": 90 * day,
+			"e.example": 90 * day,
+		},
+	}
+
+	ts := newTestServer(t, l, Config{Concurrency: 2, Timeout: 5 * time.Second})
+
+	body := `{"domains": ["c.example", "missing.example", "a.example", "b.example", "d.example", "e.example"]}`
+
+	resp, err := http.Post(ts.URL+"/v1/check", "application/json", strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("want status %d, got %d", http.StatusOK, resp.StatusCode)
+	}
+
+	var batch BatchResponse
+	decode(t, resp, &batch)
+
+	want := []struct {
+		domain string
+		state  string
+	}{
+		{"c.example", nagios.StateCRITICALLabel},
+		{"missing.example", nagios.StateUNKNOWNLabel},
+		{"a.example", nagios.StateOKLabel},
+		{"b.example", nagios.StateWARNINGLabel},
+		{"d.example", nagios.StateOKLabel},
+		{"e.example", nagios.StateOKLabel},
+	}
+
+	if len(batch.Results) != len(want) {
+		t.Fatalf("want %d results, got %d", len(want), len(batch.Results))
+	}
+
+	for i, w := range want {
+		got := batch.Results[i]
+		if got.Domain != w.domain || got.State != w.state {
+			t.Errorf("result %d: want %s %s, got %s %s", i, w.domain, w.state, got.Domain, got.State)
+		}
+	}
+
+	if failed := batch.Results[1]; len(failed.Errors) == 0 ||
+		failed.Summary != "UNKNOWN: Error fetching WHOIS data for missing.example domain" {
+		t.Errorf("unexpected failed result: %+v", failed)
+	}
+
+	if l.maxInUse > 2 {
+		t.Errorf("want at most 2 lookups in progress, got %d", l.maxInUse)
+	}
+}
+
+// TestBatchCheckInvalid asserts that invalid batch requests are rejected.
+func TestBatchCheckInvalid(t *testing.T) {
+	t.Parallel()
+
+	tooMany := make([]string, MaxBatchDomains+1)
+	for i := range tooMany {
+		tooMany[i] = fmt.Sprintf("%q", fmt.Sprintf("d%d.example", i))
+	}
+
+	tests := map[string]struct {
+		body       string
+		wantStatus int
+	}{
+		"malformed":      {body: `{"domains": [`, wantStatus: http.StatusBadRequest},
+		"unknown field":  {body: `{"names": ["a.example"]}`, wantStatus: http.StatusBadRequest},
+		"empty":          {body: `{"domains": []}`, wantStatus: http.StatusBadRequest},
+		"invalid domain": {body: `{"domains": ["a.example", "not valid"]}`, wantStatus: http.StatusBadRequest},
+		"too many": {
+			body:       `{"domains": [` + strings.Join(tooMany, ",") + `]}`,
+			wantStatus: http.StatusRequestEntityTooLarge,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			l := &countingLookup{}
+			ts := newTestServer(t, l, Config{})
+
+			resp, err := http.Post(ts.URL+"/v1/check", "application/json", strings.NewReader(tt.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("want status %d, got %d", tt.wantStatus, resp.StatusCode)
+			}
+
+			var errResp ErrorResponse
+			decode(t, resp, &errResp)
+
+			if errResp.Error == "" {
+				t.Error("want error description")
+			}
+
+			if got := l.lookups.Load(); got != 0 {
+				t.Errorf("want no lookups, got %d", got)
+			}
+		})
+	}
+}
+
+// TestMethodNotAllowed asserts that unsupported methods are rejected.
+func TestMethodNotAllowed(t *testing.T) {
+	t.Parallel()
+
+	ts := newTestServer(t, &countingLookup{}, Config{})
+
+	resp, err := http.Post(ts.URL+"/v1/domains/example.com", "application/json", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+
+	if resp.StatusCode != http.StatusMethodNotAllowed {
+		t.Errorf("want status %d, got %d", http.StatusMethodNotAllowed, resp.StatusCode)
+	}
+}
@@ -0,0 +1,10 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-whois
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+// Package whoistest provides an in-process WHOIS (TCP port 43 protocol)
+// server serving scripted responses for use in tests.
+package whoistest
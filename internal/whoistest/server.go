@@ -0,0 +1,234 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-whois
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package whoistest
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// queryReadTimeout is the time allowed for a client to send a query after
+// connecting.
+const queryReadTimeout = 5 * time.Second
+
+// RateLimitMessage is a typical message sent by WHOIS servers when a client
+// has exceeded the permitted query rate.
+const RateLimitMessage string = "% Query rate limit exceeded. Please try again later.\r\n"
+
+// Response is a scripted response to a WHOIS query.
+type Response struct {
+
+	// Body is the raw WHOIS data sent to the client.
+	Body string
+
+	// Delay is the time to wait before sending the response.
+	Delay time.Duration
+
+	// Truncate is the optional number of bytes of Body sent before the
+	// connection is closed, simulating a truncated response. The full Body
+	// is sent if zero.
+	Truncate int
+
+	// Reset indicates whether the connection is reset instead of sending
+	// Body, causing the client read to fail.
+	Reset bool
+}
+
+// RateLimited provides a response rejecting the query due to the query rate
+// limit being exceeded.
+func RateLimited() Response {
+	return Response{Body: RateLimitMessage}
+}
+
+// Referral provides a line referring clients to the registrar WHOIS server
+// at the given address (e.g., "127.0.0.1:4343") for inclusion in a response
+// body.
+func Referral(addr string) string {
+	return "Registrar WHOIS Server: " + addr + "\r\n"
+}
+
+// Server is an in-process WHOIS server listening on a local port. Queries
+// are answered using the scripted responses for each query. Queries without
+// scripted responses receive a "No match" response.
+type Server struct {
+
+	// Addr is the address (host:port) of the server, suitable for use as
+	// the WHOIS server for queries.
+	Addr string
+
+	// listener accepts client connections.
+	listener net.Listener
+
+	// closed is closed when the server is shut down, cutting short any
+	// delayed responses.
+	closed chan struct{}
+
+	// wg tracks active connections.
+	wg sync.WaitGroup
+
+	// mu guards responses and queries.
+	mu sync.Mutex
+
+	// responses maps (lowercase) queries to the remaining scripted
+	// responses.
+	responses map[string][]Response
+
+	// queries records the queries received, in order.
+	queries []string
+}
+
+// NewServer starts a WHOIS server on a local port. The server is shut down
+// when the test completes.
+func NewServer(tb testing.TB) *Server {
+	tb.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		tb.Fatalf("failed to start WHOIS server: %v", err)
+	}
+
+	s := &Server{
+		Addr:      listener.Addr().String(),
+		listener:  listener,
+		closed:    make(chan struct{}),
+		responses: make(map[string][]Response),
+	}
+
+	s.wg.Add(1)
+	go s.serve()
+
+	tb.Cleanup(s.Close)
+
+	return s
+}
+
+// Port provides the port the server is listening on.
+func (s *Server) Port() string {
+	_, port, _ := net.SplitHostPort(s.Addr)
+
+	return port
+}
+
+// Handle scripts the responses to the given query (e.g., a domain name).
+// Responses are sent in order for repeated queries with the last response
+// used for any further queries.
+func (s *Server) Handle(query string, responses ...Response) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.responses[strings.ToLower(query)] = responses
+}
+
+// Queries provides the queries received by the server, in order.
+func (s *Server) Queries() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	queries := make([]string, len(s.queries))
+	copy(queries, s.queries)
+
+	return queries
+}
+
+// Close shuts down the server, waiting for active connections to finish.
+func (s *Server) Close() {
+	select {
+	case <-s.closed:
+		return
+	default:
+		close(s.closed)
+	}
+
+	_ = s.listener.Close()
+	s.wg.Wait()
+}
+
+// serve accepts client connections until the server is shut down.
+func (s *Server) serve() {
+	defer s.wg.Done()
+
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			defer func() { _ = conn.Close() }()
+
+			s.handle(conn)
+		}()
+	}
+}
+
+// handle reads a query from the given connection and sends the scripted
+// response.
+func (s *Server) handle(conn net.Conn) {
+	_ = conn.SetReadDeadline(time.Now().Add(queryReadTimeout))
+
+	query, err := bufio.NewReader(conn).ReadString('\n')
+	if err != nil {
+		return
+	}
+
+	response := s.next(strings.TrimSpace(query))
+
+	if response.Delay > 0 {
+		timer := time.NewTimer(response.Delay)
+		defer timer.Stop()
+
+		select {
+		case <-timer.C:
+		case <-s.closed:
+			return
+		}
+	}
+
+	if response.Reset {
+		if tcpConn, ok := conn.(*net.TCPConn); ok {
+			_ = tcpConn.SetLinger(0)
+		}
+
+		return
+	}
+
+	body := response.Body
+	if response.Truncate > 0 && response.Truncate < len(body) {
+		body = body[:response.Truncate]
+	}
+
+	_, _ = conn.Write([]byte(body))
+}
+
+// next records the given query and provides the next scripted response for
+// it.
+func (s *Server) next(query string) Response {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.queries = append(s.queries, query)
+
+	key := strings.ToLower(query)
+	responses, ok := s.responses[key]
+	if !ok || len(responses) == 0 {
+		return Response{Body: fmt.Sprintf("No match for %q.\r\n", query)}
+	}
+
+	if len(responses) > 1 {
+		s.responses[key] = responses[1:]
+	}
+
+	return responses[0]
+}
@@ -0,0 +1,72 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-whois
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package whoistest
+
+import (
+	"io"
+	"net"
+	"testing"
+)
+
+// query sends the given query to the WHOIS server at the given address,
+// returning the response.
+func query(t *testing.T, addr string, q string) string {
+	t.Helper()
+
+	conn, err := net.Dial("tcp", addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = conn.Close() }()
+
+	if _, err := conn.Write([]byte(q + "\r\n")); err != nil {
+		t.Fatal(err)
+	}
+
+	response, err := io.ReadAll(conn)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(response)
+}
+
+// TestServerScriptedResponses asserts that scripted responses are served in
+// order, that truncated responses are cut short and that unscripted queries
+// receive a "No match" response.
+func TestServerScriptedResponses(t *testing.T) {
+	t.Parallel()
+
+	s := NewServer(t)
+	s.Handle("Example.com",
+		RateLimited(),
+		Response{Body: "Domain Name: EXAMPLE.COM\r\n", Truncate: 6},
+		Response{Body: "Domain Name: EXAMPLE.COM\r\n"},
+	)
+
+	want := []string{
+		RateLimitMessage,
+		"Domain",
+		"Domain Name: EXAMPLE.COM\r\n",
+		"Domain Name: EXAMPLE.COM\r\n",
+	}
+
+	for i, w := range want {
+		if got := query(t, s.Addr, "example.com"); got != w {
+			t.Errorf("query %d: want %q, got %q", i+1, w, got)
+		}
+	}
+
+	if got, w := query(t, s.Addr, "example.net"), "No match for \"example.net\".\r\n"; got != w {
+		t.Errorf("want %q, got %q", w, got)
+	}
+
+	if got := len(s.Queries()); got != len(want)+1 {
+		t.Errorf("want %d queries recorded, got %d", len(want)+1, got)
+	}
+}
@@ -17,7 +17,10 @@ project_releases="${project_repo}/releases"
 project_issues="${project_repo}/issues"
 project_discussions="${project_repo}/discussions"
 
-plugin_name="check_whois"
+plugin_names=(
+    "check_whois"
+    "check_lookalikes"
+)
 plugin_path="/usr/lib/nagios/plugins"
 
 echo